@@ -0,0 +1,537 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	currdir, err := os.Getwd()
+	if err != nil {
+		printAndExit(err)
+	}
+	var (
+		databaseFlag  = flag.String("database", "", "(required) Path to the SQLite database file. A file: URI is also accepted")
+		directoryFlag = flag.String("directory", filepath.Join(currdir, "tables"), "(optional) Directory to place the generated file. Can be absolute or relative filepath")
+		dryrunFlag    = flag.Bool("dryrun", false, "(optional) Print the list of tables to be generated without generating the file")
+		fileFlag      = flag.String("file", "tables.go", "(optional) Name of the file to be generated. If file already exists, -overwrite flag must be specified to overwrite the file")
+		overwriteFlag = flag.Bool("overwrite", false, "(optional) Overwrite any files that already exist")
+		packageFlag   = flag.String("package", "tables", "(optional) Package name of the file to be generated")
+		schemasFlag   = flag.String("schema", "main", "(optional) A comma separated list of schemas (attached databases) that you want to generate tables for. Please don't include any spaces")
+	)
+	flag.Parse()
+	log.SetFlags(log.Lshortfile)
+	if len(os.Args[1:]) == 0 {
+		flag.PrintDefaults()
+		return
+	}
+	config, err := prepConfig(*databaseFlag, *schemasFlag, *directoryFlag, *fileFlag, *packageFlag, *dryrunFlag, *overwriteFlag)
+	if err != nil {
+		printAndExit(err)
+	}
+	db, err := sql.Open("sqlite3", config.databaseURL)
+	if err != nil {
+		printAndExit(err)
+	}
+	err = db.Ping()
+	if err != nil {
+		printAndExit("Could not ping the database, is the database reachable via " + config.databaseURL + "? " + err.Error())
+	}
+
+	//--------------------------------------------------------------------------------//
+	// This is the step you should be modifying if you wish to control what
+	// tables get generated. Any tables you pass on to the next step will be
+	// written into the file. You can filter tables, modify names, or directly
+	// edit the getTables/processTables functions. You can split tables by
+	// schema and write them to separate packages and files by calling
+	// writeTablesToFile repeatedly below.
+	tables, err := processTables(getTables(db, config.schemas))
+	if err != nil {
+		printAndExit(err)
+	}
+	if config.dryrun {
+		// Here is where you can print the list of filtered tables before you
+		// actually write it out to a file.
+		for _, table := range tables {
+			fmt.Println(table)
+		}
+		return
+	}
+	//--------------------------------------------------------------------------------//
+
+	err = writeTablesToFile(tables, config.directory, config.file, config.packageName)
+	if err != nil {
+		printAndExit(err)
+	}
+	fmt.Println("Result:      ", strconv.Itoa(len(tables)), "tables written into", filepath.Join(config.directory, config.file))
+}
+
+type Table struct {
+	Schema      string
+	Name        string
+	StructName  string
+	RawType     string
+	Constructor string
+	Fields      []Field
+}
+
+type Field struct {
+	Name        string
+	RawType     string
+	Type        string
+	Constructor string
+}
+
+// getTables will get all tables in a database for a list of schemas. It does
+// not do any column type classification (i.e. which column is of type string,
+// which column is of type integer etc). getTables simply stores the declared
+// type of the column into field.RawType, where it will be classified later by
+// processTables.
+//
+// Tables belonging to the main schema are given the schema "public", so that
+// they are rendered without a schema qualifier and the generated code is
+// identical to what qygentable-postgres generates for the same tables.
+func getTables(db *sql.DB, schemas []string) ([]Table, error) {
+	var tables []Table
+	tableIndices := make(map[string]int)
+	for _, schema := range schemas {
+		query := "SELECT m.type, m.name, p.name, p.type" +
+			" FROM " + quoteIdentifier(schema) + ".sqlite_master AS m" +
+			" JOIN pragma_table_info(m.name, ?) AS p" +
+			" WHERE m.type IN ('table', 'view') AND m.name NOT LIKE 'sqlite_%'" +
+			" ORDER BY m.type, m.name, p.name"
+		err := func() error {
+			rows, err := db.Query(query, schema)
+			fmt.Println("Query:       ", query, []interface{}{schema})
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			for rows.Next() {
+				// Each row represents a specific column of specific table in the database
+				var tableType, tableName, columnName, columnType string
+				err := rows.Scan(&tableType, &tableName, &columnName, &columnType)
+				if err != nil {
+					return err
+				}
+				tableSchema := schema
+				if tableSchema == "main" {
+					tableSchema = "public"
+				}
+				switch tableType {
+				case "table":
+					tableType = TableTypeBaseTable
+				case "view":
+					tableType = TableTypeView
+				}
+				tableSchema = strings.ReplaceAll(tableSchema, " ", "_")
+				tableName = strings.ReplaceAll(tableName, " ", "_")
+				columnName = strings.ReplaceAll(columnName, " ", "_")
+				fullTableName := tableSchema + "." + tableName
+				if _, ok := tableIndices[fullTableName]; !ok {
+					// create new table
+					table := Table{
+						Schema:  tableSchema,
+						Name:    tableName,
+						RawType: tableType,
+					}
+					tables = append(tables, table)
+					tableIndices[fullTableName] = len(tables) - 1
+				}
+				// create new field
+				field := Field{
+					Name:    columnName,
+					RawType: columnType,
+				}
+				index := tableIndices[fullTableName]
+				tables[index].Fields = append(tables[index].Fields, field)
+			}
+			return rows.Err()
+		}()
+		if err != nil {
+			return tables, err
+		}
+	}
+	return tables, nil
+}
+
+const (
+	// sqlite_master tables and views are mapped onto the information_schema
+	// table types so that the same template can be used
+	TableTypeBaseTable = "BASE TABLE"
+	TableTypeView      = "VIEW"
+
+	FieldTypeBoolean = "qx.BooleanField"
+	FieldTypeJSON    = "qx.JSONField"
+	FieldTypeNumber  = "qx.NumberField"
+	FieldTypeString  = "qx.StringField"
+	FieldTypeTime    = "qx.TimeField"
+	FieldTypeBinary  = "qx.BinaryField"
+
+	FieldConstructorBoolean = "qx.NewBooleanField"
+	FieldConstructorJSON    = "qx.NewJSONField"
+	FieldConstructorNumber  = "qx.NewNumberField"
+	FieldConstructorString  = "qx.NewStringField"
+	FieldConstructorTime    = "qx.NewTimeField"
+	FieldConstructorBinary  = "qx.NewBinaryField"
+)
+
+// processTables will walk through each table and its columns (fields) and annotate
+// the table.StructName, table.Constructor, field.Type, field.Constructor based
+// on table.RawType and field.RawType.
+func processTables(inputTables []Table, err error) ([]Table, error) {
+	if err != nil {
+		return inputTables, err
+	}
+	var outputTables []Table
+	for _, table := range inputTables {
+		switch table.RawType {
+		case TableTypeBaseTable:
+			if table.Schema == "public" {
+				table.StructName = "TABLE_" + strings.ToUpper(table.Name)
+				table.Constructor = strings.ToUpper(table.Name)
+			} else {
+				table.StructName = "TABLE_" + strings.ToUpper(table.Schema+"__"+table.Name)
+				table.Constructor = strings.ToUpper(table.Schema + "__" + table.Name)
+			}
+		case TableTypeView:
+			if table.Schema == "public" {
+				table.StructName = "VIEW_" + strings.ToUpper(table.Name)
+				table.Constructor = strings.ToUpper(table.Name)
+			} else {
+				table.StructName = "VIEW_" + strings.ToUpper(table.Schema+"__"+table.Name)
+				table.Constructor = strings.ToUpper(table.Schema + "__" + table.Name)
+			}
+		default:
+			continue
+		}
+		var fields []Field
+		for _, field := range table.Fields {
+			switch {
+			case isBoolean(field.RawType):
+				field.Type = FieldTypeBoolean
+				field.Constructor = FieldConstructorBoolean
+			case isJSON(field.RawType):
+				field.Type = FieldTypeJSON
+				field.Constructor = FieldConstructorJSON
+			case isTime(field.RawType):
+				field.Type = FieldTypeTime
+				field.Constructor = FieldConstructorTime
+			case isNumber(field.RawType):
+				field.Type = FieldTypeNumber
+				field.Constructor = FieldConstructorNumber
+			case isString(field.RawType):
+				field.Type = FieldTypeString
+				field.Constructor = FieldConstructorString
+			case isBinary(field.RawType):
+				field.Type = FieldTypeBinary
+				field.Constructor = FieldConstructorBinary
+			default:
+				continue
+			}
+			fields = append(fields, field)
+		}
+		table.Fields = fields
+		outputTables = append(outputTables, table)
+	}
+	return outputTables, nil
+}
+
+type FileData struct {
+	PackageName string
+	Imports     []string
+	Tables      []Table
+}
+
+var Imports = []string{
+	"github.com/bokwoon95/qy/qx",
+}
+
+var qygenTemplate = `// Code generated by qygentable-sqlite3; DO NOT EDIT.
+package {{$.PackageName}}
+
+import (
+	{{- range $_, $import := $.Imports}}
+	"{{$import}}"
+	{{- end}}
+)
+{{- range $_, $table := $.Tables}}
+{{template "table_struct_definition" $table}}
+{{template "table_constructor" $table}}
+{{template "table_as" $table}}
+{{- end}}
+
+{{- define "table_struct_definition"}}
+{{- with $table := .}}
+{{- if eq $table.RawType "BASE TABLE"}}
+// {{uppercase $table.StructName}} references the {{$table.Schema}}.{{$table.Name}} table
+{{- else if eq $table.RawType "VIEW"}}
+// {{uppercase $table.StructName}} references the {{$table.Schema}}.{{$table.Name}} view
+{{- end}}
+type {{uppercase $table.StructName}} struct {
+	*qx.TableInfo
+	{{- range $_, $field := $table.Fields}}
+	{{uppercase $field.Name}} {{$field.Type}}
+	{{- end}}
+}
+{{- end}}
+{{- end}}
+
+{{- define "table_constructor"}}
+{{- with $table := .}}
+{{- if eq $table.RawType "BASE TABLE"}}
+// {{$table.Constructor}} creates an instance of the {{$table.Schema}}.{{$table.Name}} table
+{{- else if eq $table.RawType "VIEW"}}
+// {{$table.Constructor}} creates an instance of the {{$table.Schema}}.{{$table.Name}} view
+{{- end}}
+func {{$table.Constructor}}() {{$table.StructName}} {
+	tbl := {{$table.StructName}}{TableInfo: &qx.TableInfo{
+		Schema: "{{$table.Schema}}",
+		Name: "{{$table.Name}}",
+	},}
+	{{- range $_, $field := $table.Fields}}
+	tbl.{{uppercase $field.Name}} = {{$field.Constructor}}("{{$field.Name}}", tbl.TableInfo)
+	{{- end}}
+	return tbl
+}
+{{- end}}
+{{- end}}
+
+{{- define "table_as"}}
+{{- with $table := .}}
+func (tbl {{$table.StructName}}) As(alias string) {{$table.StructName}} {
+	tbl.TableInfo.Alias = alias
+	return tbl
+}
+{{- end}}
+{{- end}}`
+
+// writeTablesToFile will write the tables into a file specified by
+// filepath.Join(directory, file).
+func writeTablesToFile(tables []Table, directory, file, packageName string) error {
+	err := os.MkdirAll(directory, 0755)
+	if err != nil {
+		return fmt.Errorf("Could not create directory %s: %w", directory, err)
+	}
+	filename := filepath.Join(directory, file)
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	t, err := template.New("").Funcs(template.FuncMap{"uppercase": strings.ToUpper}).Parse(qygenTemplate)
+	if err != nil {
+		return err
+	}
+	data := FileData{
+		PackageName: packageName,
+		Imports:     Imports,
+		Tables:      tables,
+	}
+	err = t.Execute(f, data)
+	if err != nil {
+		return err
+	}
+	if _, err := exec.LookPath("goimports"); err == nil {
+		_ = exec.Command("goimports", "-w", filename).Run()
+	} else if _, err := exec.LookPath("gofmt"); err == nil {
+		_ = exec.Command("gofmt", "-w", filename).Run()
+	}
+	return nil
+}
+
+type Config struct {
+	databaseURL string
+	directory   string
+	dryrun      bool
+	file        string
+	overwrite   bool
+	packageName string
+	schemas     []string
+}
+
+// prepConfig will process the incoming data and initialize the config object
+// accordingly
+func prepConfig(database, schemas, directory, file, packageName string, dryrun, overwrite bool) (cfg Config, err error) {
+	// databaseURL
+	cfg.databaseURL = database
+	fmt.Println("Database:    ", cfg.databaseURL)
+	if cfg.databaseURL == "" {
+		return cfg, fmt.Errorf("Database file is either empty or not passed in. You need to specify a database file with the -database option.")
+	}
+	if !strings.HasPrefix(cfg.databaseURL, "file:") {
+		// sqlite3 silently creates a new database if the file does not exist
+		if _, err := os.Stat(cfg.databaseURL); err != nil {
+			return cfg, fmt.Errorf("Could not open database file %s: %w", cfg.databaseURL, err)
+		}
+	}
+
+	// schemas
+	if schemas == "" {
+		return cfg, fmt.Errorf("At least one database schema needs to be specified")
+	}
+	cfg.schemas = strings.FieldsFunc(schemas, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	fmt.Println("Schemas:     ", cfg.schemas)
+
+	// directory
+	cfg.directory = directory
+	fmt.Println("Directory:   ", cfg.directory)
+	if cfg.directory == "" {
+		return cfg, fmt.Errorf("-directory was not specified. You need to provide a directory to place the generated file in")
+	}
+	cfg.directory, err = filepath.Abs(cfg.directory)
+	if err != nil {
+		return cfg, err
+	}
+
+	// file
+	cfg.file = file
+	fmt.Println("File:        ", cfg.file)
+	if cfg.file == "" {
+		return cfg, fmt.Errorf("-file was not specified. You need to provide a file name (e.g. tables.go) for the generated file")
+	}
+	if !strings.HasSuffix(cfg.file, ".go") {
+		cfg.file = cfg.file + ".go"
+	}
+	asboluteFilePath := filepath.Join(directory, file)
+	if _, err := os.Stat(asboluteFilePath); err == nil && !overwrite {
+		return cfg, fmt.Errorf("Specified file %s already exists. If you wish to overwrite it, provide the -overwrite flag", asboluteFilePath)
+	}
+
+	// packageName
+	cfg.packageName = packageName
+	fmt.Println("Package Name:", cfg.packageName)
+	if cfg.packageName == "" {
+		return cfg, fmt.Errorf("-package name was not provided. You need to provide the package name for the generated file")
+	}
+
+	// dryrun
+	cfg.dryrun = dryrun
+	return cfg, nil
+}
+
+/* Utility functions */
+
+func printAndExit(v ...interface{}) {
+	fmt.Println("======================================== ERROR! ========================================")
+	log.Output(2, fmt.Sprintln(v...))
+	os.Exit(1)
+}
+
+// quoteIdentifier will quote a schema name so that it can be safely used in
+// a query string.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// String implements fmt.Stringer for type Table, allowing you to call
+// fmt.Println(table) and have it formatted accordingly.
+func (table Table) String() string {
+	var output string
+	if table.Constructor != "" && table.StructName != "" {
+		output += fmt.Sprintf("%s.%s => func %s() %s\n", table.Schema, table.Name, table.Constructor, table.StructName)
+	} else {
+		output += fmt.Sprintf("%s.%s\n", table.Schema, table.Name)
+	}
+	for _, field := range table.Fields {
+		if field.Constructor != "" && field.Type != "" {
+			output += fmt.Sprintf("    %s: %s => %s\n", field.Name, field.RawType, field.Type)
+		} else {
+			output += fmt.Sprintf("    %s: %s\n", field.Name, field.RawType)
+		}
+	}
+	return output
+}
+
+/* Type classification functions */
+
+// https://www.sqlite.org/datatype3.html#type_affinity
+const (
+	AffinityInteger = "INTEGER"
+	AffinityText    = "TEXT"
+	AffinityBlob    = "BLOB"
+	AffinityReal    = "REAL"
+	AffinityNumeric = "NUMERIC"
+)
+
+// affinity returns the type affinity of a declared column type, following the
+// rules in https://www.sqlite.org/datatype3.html#determination_of_column_affinity.
+func affinity(rawtype string) string {
+	rawtype = strings.ToUpper(rawtype)
+	switch {
+	case strings.Contains(rawtype, "INT"):
+		return AffinityInteger
+	case strings.Contains(rawtype, "CHAR"), strings.Contains(rawtype, "CLOB"), strings.Contains(rawtype, "TEXT"):
+		return AffinityText
+	case strings.Contains(rawtype, "BLOB"), rawtype == "":
+		return AffinityBlob
+	case strings.Contains(rawtype, "REAL"), strings.Contains(rawtype, "FLOA"), strings.Contains(rawtype, "DOUB"):
+		return AffinityReal
+	default:
+		return AffinityNumeric
+	}
+}
+
+// typeName returns the declared column type without any size arguments i.e.
+// "DECIMAL" for "decimal(10, 5)".
+func typeName(rawtype string) string {
+	if i := strings.Index(rawtype, "("); i >= 0 {
+		rawtype = rawtype[:i]
+	}
+	return strings.ToUpper(strings.TrimSpace(rawtype))
+}
+
+func isBoolean(rawtype string) bool {
+	// BOOLEAN has NUMERIC affinity, but it is clear what the column is for
+	switch typeName(rawtype) {
+	case "BOOL", "BOOLEAN":
+		return true
+	default:
+		return false
+	}
+}
+
+func isJSON(rawtype string) bool {
+	// https://www.sqlite.org/json1.html
+	return typeName(rawtype) == "JSON"
+}
+
+func isTime(rawtype string) bool {
+	// https://www.sqlite.org/lang_datefunc.html
+	// DATE, DATETIME and TIMESTAMP have NUMERIC affinity, but they are what
+	// the go-sqlite3 driver converts to and from time.Time
+	switch typeName(rawtype) {
+	case "DATE", "DATETIME", "TIMESTAMP", "TIME":
+		return true
+	default:
+		return false
+	}
+}
+
+func isNumber(rawtype string) bool {
+	switch affinity(rawtype) {
+	case AffinityInteger, AffinityReal, AffinityNumeric:
+		return true
+	default:
+		return false
+	}
+}
+
+func isString(rawtype string) bool {
+	return affinity(rawtype) == AffinityText
+}
+
+func isBinary(rawtype string) bool {
+	return affinity(rawtype) == AffinityBlob
+}
@@ -4,7 +4,8 @@ go 1.14
 
 require (
 	github.com/DATA-DOG/go-txdb v0.1.3
-	github.com/go-sql-driver/mysql v1.5.0 // indirect
+	github.com/go-sql-driver/mysql v1.5.0
 	github.com/lib/pq v1.5.2
 	github.com/matryer/is v1.3.0
+	github.com/mattn/go-sqlite3 v1.14.0
 )
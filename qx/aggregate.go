@@ -0,0 +1,395 @@
+package qx
+
+import (
+	"strings"
+)
+
+// aggregate represents an SQL aggregate function call e.g. 'COUNT(DISTINCT
+// field)' or 'string_agg(field, ? ORDER BY field) FILTER (WHERE predicate)'.
+// It is not used directly, instead it is wrapped in a typed Field (see Count,
+// Sum, StringAgg etc) so that the result of the aggregate function can be used
+// like any other Field of that type e.g. row.Int(Count(field)).
+type aggregate struct {
+	name     string
+	args     []interface{}
+	distinct bool
+	orderBy  Fields
+	filter   VariadicPredicate
+}
+
+// ToSQLExclude marshals an aggregate into an SQL query and args. The list of
+// table qualifiers to be excluded is propagated down to the arguments, the
+// ORDER BY Fields and the FILTER predicates.
+func (a aggregate) ToSQLExclude(excludeTableQualifiers []string) (string, []interface{}) {
+	buf := &strings.Builder{}
+	var args []interface{}
+	buf.WriteString(a.name + "(")
+	if a.distinct {
+		buf.WriteString("DISTINCT ")
+	}
+	if len(a.args) == 0 {
+		buf.WriteString("*")
+	} else {
+		format := "?" + strings.Repeat(", ?", len(a.args)-1)
+		argsQuery, argsArgs := FormatPreprocessor(format, a.args, excludeTableQualifiers)
+		buf.WriteString(argsQuery)
+		args = append(args, argsArgs...)
+	}
+	a.orderBy.WriteSQL(buf, &args, "ORDER BY ", "", excludeTableQualifiers)
+	buf.WriteString(")")
+	a.filter.Toplevel = true
+	a.filter.WriteSQL(buf, &args, "FILTER (WHERE ", ")", excludeTableQualifiers)
+	return buf.String(), args
+}
+
+// GetAlias implements the Field interface. It always returns an empty string
+// because the alias belongs to the typed Field wrapping the aggregate.
+func (a aggregate) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Field interface. It always returns an empty string
+// because aggregates do not have names.
+func (a aggregate) GetName() string {
+	return ""
+}
+
+// withDistinct returns the aggregate with the DISTINCT modifier. It panics
+// for 'COUNT(*)', because 'COUNT(DISTINCT *)' is not valid SQL.
+func (a aggregate) withDistinct() aggregate {
+	if len(a.args) == 0 {
+		panic("DISTINCT cannot be used with " + a.name + "(*)")
+	}
+	a.distinct = true
+	return a
+}
+
+// withOrderBy returns the aggregate with the fields added to its ORDER BY. It
+// panics for 'COUNT(*)', which has nothing to order.
+func (a aggregate) withOrderBy(fields []Field) aggregate {
+	if len(a.args) == 0 {
+		panic("ORDER BY cannot be used with " + a.name + "(*)")
+	}
+	orderBy := make(Fields, len(a.orderBy), len(a.orderBy)+len(fields))
+	copy(orderBy, a.orderBy)
+	a.orderBy = append(orderBy, fields...)
+	return a
+}
+
+// withFilter returns the aggregate with the predicates added to its FILTER
+// (WHERE ...) clause.
+func (a aggregate) withFilter(predicates []Predicate) aggregate {
+	filter := make([]Predicate, len(a.filter.Predicates), len(a.filter.Predicates)+len(predicates))
+	copy(filter, a.filter.Predicates)
+	a.filter.Predicates = append(filter, predicates...)
+	return a
+}
+
+// modifyAggregate returns the values of a typed Field with its aggregate
+// function modified. It panics if the typed Field (described by its format and
+// values) is not an aggregate function, since the modifier would otherwise be
+// silently ignored.
+func modifyAggregate(format *string, values []interface{}, modifier string, modify func(aggregate) aggregate) []interface{} {
+	if format == nil || *format != "?" || len(values) != 1 {
+		panic(modifier + " can only be used on an aggregate function")
+	}
+	a, ok := values[0].(aggregate)
+	if !ok {
+		panic(modifier + " can only be used on an aggregate function")
+	}
+	return []interface{}{modify(a)}
+}
+
+// Distinct returns a new NumberField with the DISTINCT modifier added to its
+// aggregate function i.e. 'name(DISTINCT field)'. It panics if the NumberField
+// is not an aggregate function.
+func (f NumberField) Distinct() NumberField {
+	f.values = modifyAggregate(f.format, f.values, "DISTINCT", func(a aggregate) aggregate {
+		return a.withDistinct()
+	})
+	return f
+}
+
+// OrderBy returns a new NumberField with the fields added to the ORDER BY of its
+// aggregate function i.e. 'name(field ORDER BY fields)'. It panics if the
+// NumberField is not an aggregate function.
+func (f NumberField) OrderBy(fields ...Field) NumberField {
+	f.values = modifyAggregate(f.format, f.values, "ORDER BY", func(a aggregate) aggregate {
+		return a.withOrderBy(fields)
+	})
+	return f
+}
+
+// Filter returns a new NumberField with the predicates added to the FILTER clause of
+// its aggregate function i.e. 'name(field) FILTER (WHERE predicates)'. It
+// panics if the NumberField is not an aggregate function.
+func (f NumberField) Filter(predicates ...Predicate) NumberField {
+	f.values = modifyAggregate(f.format, f.values, "FILTER", func(a aggregate) aggregate {
+		return a.withFilter(predicates)
+	})
+	return f
+}
+
+// Distinct returns a new StringField with the DISTINCT modifier added to its
+// aggregate function i.e. 'name(DISTINCT field)'. It panics if the StringField
+// is not an aggregate function.
+func (f StringField) Distinct() StringField {
+	f.values = modifyAggregate(f.format, f.values, "DISTINCT", func(a aggregate) aggregate {
+		return a.withDistinct()
+	})
+	return f
+}
+
+// OrderBy returns a new StringField with the fields added to the ORDER BY of its
+// aggregate function i.e. 'name(field ORDER BY fields)'. It panics if the
+// StringField is not an aggregate function.
+func (f StringField) OrderBy(fields ...Field) StringField {
+	f.values = modifyAggregate(f.format, f.values, "ORDER BY", func(a aggregate) aggregate {
+		return a.withOrderBy(fields)
+	})
+	return f
+}
+
+// Filter returns a new StringField with the predicates added to the FILTER clause of
+// its aggregate function i.e. 'name(field) FILTER (WHERE predicates)'. It
+// panics if the StringField is not an aggregate function.
+func (f StringField) Filter(predicates ...Predicate) StringField {
+	f.values = modifyAggregate(f.format, f.values, "FILTER", func(a aggregate) aggregate {
+		return a.withFilter(predicates)
+	})
+	return f
+}
+
+// Distinct returns a new TimeField with the DISTINCT modifier added to its
+// aggregate function i.e. 'name(DISTINCT field)'. It panics if the TimeField
+// is not an aggregate function.
+func (f TimeField) Distinct() TimeField {
+	f.values = modifyAggregate(f.format, f.values, "DISTINCT", func(a aggregate) aggregate {
+		return a.withDistinct()
+	})
+	return f
+}
+
+// OrderBy returns a new TimeField with the fields added to the ORDER BY of its
+// aggregate function i.e. 'name(field ORDER BY fields)'. It panics if the
+// TimeField is not an aggregate function.
+func (f TimeField) OrderBy(fields ...Field) TimeField {
+	f.values = modifyAggregate(f.format, f.values, "ORDER BY", func(a aggregate) aggregate {
+		return a.withOrderBy(fields)
+	})
+	return f
+}
+
+// Filter returns a new TimeField with the predicates added to the FILTER clause of
+// its aggregate function i.e. 'name(field) FILTER (WHERE predicates)'. It
+// panics if the TimeField is not an aggregate function.
+func (f TimeField) Filter(predicates ...Predicate) TimeField {
+	f.values = modifyAggregate(f.format, f.values, "FILTER", func(a aggregate) aggregate {
+		return a.withFilter(predicates)
+	})
+	return f
+}
+
+// Distinct returns a new BooleanField with the DISTINCT modifier added to its
+// aggregate function i.e. 'name(DISTINCT field)'. It panics if the BooleanField
+// is not an aggregate function.
+func (f BooleanField) Distinct() BooleanField {
+	f.values = modifyAggregate(f.format, f.values, "DISTINCT", func(a aggregate) aggregate {
+		return a.withDistinct()
+	})
+	return f
+}
+
+// OrderBy returns a new BooleanField with the fields added to the ORDER BY of its
+// aggregate function i.e. 'name(field ORDER BY fields)'. It panics if the
+// BooleanField is not an aggregate function.
+func (f BooleanField) OrderBy(fields ...Field) BooleanField {
+	f.values = modifyAggregate(f.format, f.values, "ORDER BY", func(a aggregate) aggregate {
+		return a.withOrderBy(fields)
+	})
+	return f
+}
+
+// Filter returns a new BooleanField with the predicates added to the FILTER clause of
+// its aggregate function i.e. 'name(field) FILTER (WHERE predicates)'. It
+// panics if the BooleanField is not an aggregate function.
+func (f BooleanField) Filter(predicates ...Predicate) BooleanField {
+	f.values = modifyAggregate(f.format, f.values, "FILTER", func(a aggregate) aggregate {
+		return a.withFilter(predicates)
+	})
+	return f
+}
+
+// Distinct returns a new JSONField with the DISTINCT modifier added to its
+// aggregate function i.e. 'name(DISTINCT field)'. It panics if the JSONField
+// is not an aggregate function.
+func (f JSONField) Distinct() JSONField {
+	f.values = modifyAggregate(f.format, f.values, "DISTINCT", func(a aggregate) aggregate {
+		return a.withDistinct()
+	})
+	return f
+}
+
+// OrderBy returns a new JSONField with the fields added to the ORDER BY of its
+// aggregate function i.e. 'name(field ORDER BY fields)'. It panics if the
+// JSONField is not an aggregate function.
+func (f JSONField) OrderBy(fields ...Field) JSONField {
+	f.values = modifyAggregate(f.format, f.values, "ORDER BY", func(a aggregate) aggregate {
+		return a.withOrderBy(fields)
+	})
+	return f
+}
+
+// Filter returns a new JSONField with the predicates added to the FILTER clause of
+// its aggregate function i.e. 'name(field) FILTER (WHERE predicates)'. It
+// panics if the JSONField is not an aggregate function.
+func (f JSONField) Filter(predicates ...Predicate) JSONField {
+	f.values = modifyAggregate(f.format, f.values, "FILTER", func(a aggregate) aggregate {
+		return a.withFilter(predicates)
+	})
+	return f
+}
+
+// Distinct returns a new ArrayField with the DISTINCT modifier added to its
+// aggregate function i.e. 'name(DISTINCT field)'. It panics if the ArrayField
+// is not an aggregate function.
+func (f ArrayField) Distinct() ArrayField {
+	f.values = modifyAggregate(f.format, f.values, "DISTINCT", func(a aggregate) aggregate {
+		return a.withDistinct()
+	})
+	return f
+}
+
+// OrderBy returns a new ArrayField with the fields added to the ORDER BY of its
+// aggregate function i.e. 'name(field ORDER BY fields)'. It panics if the
+// ArrayField is not an aggregate function.
+func (f ArrayField) OrderBy(fields ...Field) ArrayField {
+	f.values = modifyAggregate(f.format, f.values, "ORDER BY", func(a aggregate) aggregate {
+		return a.withOrderBy(fields)
+	})
+	return f
+}
+
+// Filter returns a new ArrayField with the predicates added to the FILTER clause of
+// its aggregate function i.e. 'name(field) FILTER (WHERE predicates)'. It
+// panics if the ArrayField is not an aggregate function.
+func (f ArrayField) Filter(predicates ...Predicate) ArrayField {
+	f.values = modifyAggregate(f.format, f.values, "FILTER", func(a aggregate) aggregate {
+		return a.withFilter(predicates)
+	})
+	return f
+}
+
+// CountAll returns a new NumberField representing the 'COUNT(*)' aggregate
+// function. It can only be modified with Filter, because 'COUNT(DISTINCT *)'
+// is not valid SQL.
+func CountAll() NumberField {
+	return NumberFieldf("?", aggregate{name: "COUNT"})
+}
+
+// Count returns a new NumberField representing the 'COUNT(field)'
+// aggregate function. Use CountAll for 'COUNT(*)'.
+func Count(field Field) NumberField {
+	return NumberFieldf("?", aggregate{name: "COUNT", args: []interface{}{field}})
+}
+
+// CountDistinct returns a new NumberField representing the
+// 'COUNT(DISTINCT field)' aggregate function.
+func CountDistinct(field Field) NumberField {
+	return Count(field).Distinct()
+}
+
+// Sum returns a new NumberField representing the 'SUM(field)' aggregate
+// function.
+func Sum(field NumberField) NumberField {
+	return NumberFieldf("?", aggregate{name: "SUM", args: []interface{}{field}})
+}
+
+// Avg returns a new NumberField representing the 'AVG(field)' aggregate
+// function.
+func Avg(field NumberField) NumberField {
+	return NumberFieldf("?", aggregate{name: "AVG", args: []interface{}{field}})
+}
+
+// Min returns a new NumberField representing the 'MIN(field)' aggregate
+// function.
+func Min(field NumberField) NumberField {
+	return NumberFieldf("?", aggregate{name: "MIN", args: []interface{}{field}})
+}
+
+// Max returns a new NumberField representing the 'MAX(field)' aggregate
+// function.
+func Max(field NumberField) NumberField {
+	return NumberFieldf("?", aggregate{name: "MAX", args: []interface{}{field}})
+}
+
+// MinString returns a new StringField representing the 'MIN(field)'
+// aggregate function.
+func MinString(field StringField) StringField {
+	return StringFieldf("?", aggregate{name: "MIN", args: []interface{}{field}})
+}
+
+// MaxString returns a new StringField representing the 'MAX(field)'
+// aggregate function.
+func MaxString(field StringField) StringField {
+	return StringFieldf("?", aggregate{name: "MAX", args: []interface{}{field}})
+}
+
+// MinTime returns a new TimeField representing the 'MIN(field)' aggregate
+// function.
+func MinTime(field TimeField) TimeField {
+	return TimeFieldf("?", aggregate{name: "MIN", args: []interface{}{field}})
+}
+
+// MaxTime returns a new TimeField representing the 'MAX(field)' aggregate
+// function.
+func MaxTime(field TimeField) TimeField {
+	return TimeFieldf("?", aggregate{name: "MAX", args: []interface{}{field}})
+}
+
+// BoolAnd returns a new BooleanField representing the 'bool_and(field)'
+// aggregate function (postgres only).
+func BoolAnd(field BooleanField) BooleanField {
+	return BooleanFieldf("?", aggregate{name: "bool_and", args: []interface{}{field}})
+}
+
+// BoolOr returns a new BooleanField representing the 'bool_or(field)'
+// aggregate function (postgres only).
+func BoolOr(field BooleanField) BooleanField {
+	return BooleanFieldf("?", aggregate{name: "bool_or", args: []interface{}{field}})
+}
+
+// StringAgg returns a new StringField representing the 'string_agg(field,
+// separator)' aggregate function (postgres only).
+func StringAgg(field StringField, separator string) StringField {
+	return StringFieldf("?", aggregate{name: "string_agg", args: []interface{}{field, separator}})
+}
+
+// JSONAgg returns a new JSONField representing the 'json_agg(field)'
+// aggregate function (postgres only).
+func JSONAgg(field Field) JSONField {
+	return JSONFieldf("?", aggregate{name: "json_agg", args: []interface{}{field}})
+}
+
+// ArrayAgg returns a new ArrayField representing the 'array_agg(field)'
+// aggregate function (postgres only).
+func ArrayAgg(field Field) ArrayField {
+	return ArrayFieldf("?", aggregate{name: "array_agg", args: []interface{}{field}})
+}
+
+// ContainsAggregate reports whether any of the fields is, or contains, an
+// aggregate function call (see Count, Sum etc).
+func ContainsAggregate(fields ...Field) bool {
+	for i := range fields {
+		if fields[i] == nil {
+			continue
+		}
+		if containsExpression(fields[i], func(format string, v interface{}) bool {
+			_, ok := v.(aggregate)
+			return ok
+		}) {
+			return true
+		}
+	}
+	return false
+}
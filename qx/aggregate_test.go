@@ -0,0 +1,129 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAggregate_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION            string
+		f                      Field
+		excludeTableQualifiers []string
+		wantQuery              string
+		wantArgs               []interface{}
+	}
+	cust, film, pay := CUSTOMER(), FILM(), PAYMENT()
+	tests := []TT{
+		{"COUNT(*)", CountAll(), nil, "COUNT(*)", nil},
+		{"COUNT(field) with alias", Count(pay.PAYMENT_ID).As("total"), nil, "COUNT(payment.payment_id)", nil},
+		{"COUNT DISTINCT", CountDistinct(pay.CUSTOMER_ID), nil, "COUNT(DISTINCT payment.customer_id)", nil},
+		{"SUM", Sum(pay.AMOUNT), nil, "SUM(payment.amount)", nil},
+		{"AVG", Avg(pay.AMOUNT).Desc(), nil, "AVG(payment.amount) DESC", nil},
+		{"MIN", Min(pay.AMOUNT), nil, "MIN(payment.amount)", nil},
+		{"MAX", Max(pay.AMOUNT), nil, "MAX(payment.amount)", nil},
+		{"bool_and", BoolAnd(cust.ACTIVEBOOL), nil, "bool_and(customer.activebool)", nil},
+		{"bool_or", BoolOr(cust.ACTIVEBOOL), nil, "bool_or(customer.activebool)", nil},
+		{
+			"string_agg DISTINCT ORDER BY",
+			StringAgg(film.TITLE, ", ").Distinct().OrderBy(film.TITLE.Desc()),
+			nil,
+			"string_agg(DISTINCT film.title, ? ORDER BY film.title DESC)",
+			[]interface{}{", "},
+		},
+		{"json_agg", JSONAgg(film.TITLE), nil, "json_agg(film.title)", nil},
+		{"array_agg ORDER BY", ArrayAgg(film.TITLE).OrderBy(film.FILM_ID), nil, "array_agg(film.title ORDER BY film.film_id)", nil},
+		{
+			"COUNT(*) FILTER",
+			CountAll().Filter(pay.AMOUNT.GtFloat64(5), pay.STAFF_ID.EqInt(1)),
+			nil,
+			"COUNT(*) FILTER (WHERE payment.amount > ? AND payment.staff_id = ?)",
+			[]interface{}{5.0, 1},
+		},
+		{
+			"respect excludeTableQualifiers",
+			Sum(pay.AMOUNT).Filter(pay.STAFF_ID.EqInt(2)),
+			[]string{"payment"},
+			"SUM(amount) FILTER (WHERE staff_id = ?)",
+			[]interface{}{2},
+		},
+		{"MIN string", MinString(film.TITLE), nil, "MIN(film.title)", nil},
+		{"MAX string", MaxString(film.TITLE).Filter(film.LENGTH.GtInt(60)), nil, "MAX(film.title) FILTER (WHERE film.length > ?)", []interface{}{60}},
+		{"MIN time", MinTime(pay.PAYMENT_DATE), nil, "MIN(payment.payment_date)", nil},
+		{"MAX time", MaxTime(pay.PAYMENT_DATE).As("last_payment"), nil, "MAX(payment.payment_date)", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.f.ToSQLExclude(tt.excludeTableQualifiers)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestAggregate_Immutable(t *testing.T) {
+	is := is.New(t)
+	pay := PAYMENT()
+	base := CountAll().Filter(pay.STAFF_ID.EqInt(1))
+	_ = base.Filter(pay.AMOUNT.GtFloat64(5))
+	query, args := base.ToSQLExclude(nil)
+	is.Equal("COUNT(*) FILTER (WHERE payment.staff_id = ?)", query)
+	is.Equal([]interface{}{1}, args)
+}
+
+func TestAggregate_Row(t *testing.T) {
+	is := is.New(t)
+	pay := PAYMENT()
+	r := &QxRow{}
+	_ = r.Int(Count(pay.PAYMENT_ID))
+	_ = r.Float64(Avg(pay.AMOUNT).Filter(pay.STAFF_ID.EqInt(1)).As("avg_amount"))
+	_ = r.Int64(CountDistinct(pay.CUSTOMER_ID).Desc())
+	_ = r.Time(MaxTime(pay.PAYMENT_DATE))
+	is.Equal(4, len(r.Fields))
+	query, args := r.Fields[1].ToSQLExclude(nil)
+	is.Equal("AVG(payment.amount) FILTER (WHERE payment.staff_id = ?)", query)
+	is.Equal([]interface{}{1}, args)
+	is.Equal("avg_amount", r.Fields[1].GetAlias())
+	query, _ = r.Fields[2].ToSQLExclude(nil)
+	is.Equal("COUNT(DISTINCT payment.customer_id) DESC", query)
+}
+
+func TestAggregate_InvalidModifiers(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           func()
+	}
+	pay, film := PAYMENT(), FILM()
+	tests := []TT{
+		{"DISTINCT on a column", func() { pay.AMOUNT.Distinct() }},
+		{"FILTER on an expression", func() { pay.AMOUNT.Add(1).Filter(pay.STAFF_ID.EqInt(1)) }},
+		{"ORDER BY on a column", func() { film.TITLE.OrderBy(film.FILM_ID) }},
+		{"COUNT(DISTINCT *)", func() { CountAll().Distinct() }},
+		{"COUNT(* ORDER BY)", func() { CountAll().OrderBy(pay.AMOUNT) }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			defer func() {
+				is.True(recover() != nil)
+			}()
+			tt.f()
+		})
+	}
+}
+
+func TestContainsAggregate(t *testing.T) {
+	is := is.New(t)
+	pay := PAYMENT()
+	is.True(ContainsAggregate(pay.CUSTOMER_ID, CountAll()))
+	is.True(ContainsAggregate(Sum(pay.AMOUNT).Add(1)))
+	is.True(ContainsAggregate(CaseWhen(Count(pay.PAYMENT_ID).GtInt(1), "many").Else("few")))
+	is.True(ContainsAggregate(CustomField{Format: "coalesce(?, 0)", Values: []interface{}{Max(pay.AMOUNT)}}))
+	is.True(!ContainsAggregate(pay.CUSTOMER_ID, pay.AMOUNT.Add(1), nil))
+}
@@ -1,11 +1,26 @@
 package qx
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
+// ArrayField either represents an array column, an array expression or a
+// literal array value.
 type ArrayField struct {
 	// ArrayField will be one of the following:
 
-	// 1) Literal array value (only []bool, []float64, []int64 or []string
+	// 1) Array expression
+	// Examples of array expressions:
+	// | query                             | args   |
+	// |-----------------------------------|--------|
+	// | film.special_features || ARRAY[?] | extras |
+	// | array_remove(tags.names, ?)       | old    |
+	// | (film.special_features)[2:3]      |        |
+	format *string
+	values []interface{}
+
+	// 2) Literal array value (only []bool, []float64, []int64 or []string
 	// slices are supported.) Nested slices are also not supported even though
 	// both Go and Postgres support nested slices/arrays because I'm not even
 	// sure if it's possible to convert between the two with lib/pq.
@@ -20,8 +35,8 @@ type ArrayField struct {
 	// | ARRAY[?, ?, ?]    | apple, banana, cucumber |
 	value interface{}
 
-	// 2) Array column
-	// Examples of boolean columns:
+	// 3) Array column
+	// Examples of array columns:
 	// | query                 | args |
 	// |-----------------------|------|
 	// | film.special_features |      |
@@ -33,8 +48,23 @@ type ArrayField struct {
 	nullsfirst *bool
 }
 
+// ToSQL marshals an ArrayField into an SQL query and args (as described in the
+// ArrayField internal struct comments). If the ArrayField's table name appears
+// in the excludeTableQualifiers list, the output column name will not be table
+// qualified.
 func (f ArrayField) ToSQLExclude(excludeTableQualifiers []string) (string, []interface{}) {
-	// 1) Literal array value
+	// 1) Array expression
+	if f.format != nil {
+		return CustomField{
+			Alias:        f.alias,
+			Format:       *f.format,
+			Values:       f.values,
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
+		}.ToSQLExclude(excludeTableQualifiers)
+	}
+
+	// 2) Literal array value
 	if f.value != nil {
 		var query string
 		var args []interface{}
@@ -95,22 +125,28 @@ func (f ArrayField) ToSQLExclude(excludeTableQualifiers []string) (string, []int
 		return query, args
 	}
 
-	// 2) Array column
+	// 3) Array column
 	var tableQualifier string
 	if f.table != nil {
 		if f.table.GetAlias() != "" {
-			tableQualifier = f.table.GetAlias() + "."
+			tableQualifier = f.table.GetAlias()
 		} else if f.table.GetName() != "" {
-			tableQualifier = f.table.GetName() + "."
+			tableQualifier = f.table.GetName()
 		}
 	}
 	for i := range excludeTableQualifiers {
-		if tableQualifier == excludeTableQualifiers[i]+"." {
+		if tableQualifier == excludeTableQualifiers[i] {
 			tableQualifier = ""
 			break
 		}
 	}
-	columnName := tableQualifier + f.name
+	columnName := QuoteIdentifier(f.name)
+	if tableQualifier != "" {
+		if f.table.GetAlias() == "" {
+			tableQualifier = QuoteIdentifier(tableQualifier)
+		}
+		columnName = tableQualifier + "." + columnName
+	}
 	if f.descending != nil {
 		if *f.descending {
 			columnName = columnName + " DESC"
@@ -136,7 +172,19 @@ func NewArrayField(name string, table Table) ArrayField {
 	}
 }
 
-// Array returns a new ArrayField representing a literal string value.
+// ArrayFieldf follows a printf-like syntax that takes in multiple values and
+// returns an ArrayField formatted according to an arbitrary format string. The
+// only recognized format specifier is the ? question mark. E.g.
+// ArrayFieldf("array_cat(?, ?)", field1, field2). Values are interpolated as
+// described in FormatPreprocessor.
+func ArrayFieldf(format string, values ...interface{}) ArrayField {
+	return ArrayField{
+		format: &format,
+		values: values,
+	}
+}
+
+// Array returns a new ArrayField representing a literal array value.
 func Array(slice interface{}) ArrayField {
 	return ArrayField{
 		value: slice,
@@ -287,14 +335,162 @@ func (f ArrayField) Overlaps(field ArrayField) Predicate {
 	}
 }
 
-// Concat concatenates the object ArrayField to the subject ArrayField.
-func (f ArrayField) Concat(field ArrayField) Predicate {
-	return CustomPredicate{
-		Format: "? || ?",
-		Values: []interface{}{f, field},
+// Concat returns a new ArrayField representing the object ArrayField
+// concatenated to the subject ArrayField i.e. '(A || B)'.
+func (f ArrayField) Concat(field ArrayField) ArrayField {
+	return ArrayFieldf("(? || ?)", f, field)
+}
+
+// Append returns a new ArrayField representing the value appended to the end
+// of the ArrayField i.e. 'array_append(A, value)'.
+func (f ArrayField) Append(value interface{}) ArrayField {
+	return ArrayFieldf("array_append(?, ?)", f, value)
+}
+
+// Prepend returns a new ArrayField representing the value prepended to the
+// start of the ArrayField i.e. 'array_prepend(value, A)'.
+func (f ArrayField) Prepend(value interface{}) ArrayField {
+	return ArrayFieldf("array_prepend(?, ?)", value, f)
+}
+
+// Remove returns a new ArrayField representing the ArrayField with all
+// elements equal to the value removed i.e. 'array_remove(A, value)'.
+func (f ArrayField) Remove(value interface{}) ArrayField {
+	return ArrayFieldf("array_remove(?, ?)", f, value)
+}
+
+// Slice returns a new ArrayField representing the elements between the lower
+// and upper indexes (inclusive) of the ArrayField i.e. '(A)[lower:upper]'.
+// Array indexes start from 1.
+func (f ArrayField) Slice(lower, upper int) ArrayField {
+	return ArrayFieldf("(?)["+strconv.Itoa(lower)+":"+strconv.Itoa(upper)+"]", f)
+}
+
+// At returns a new CustomField representing the i-th element of the
+// ArrayField i.e. '(A)[i]'. Array indexes start from 1. Use AtNumber,
+// AtString, AtBoolean or AtTime to get a typed Field instead.
+func (f ArrayField) At(i int) CustomField {
+	return CustomField{
+		Format: "(?)[" + strconv.Itoa(i) + "]",
+		Values: []interface{}{f},
+	}
+}
+
+// AtNumber returns a new NumberField representing the i-th element of the
+// ArrayField i.e. '(A)[i]'. Array indexes start from 1.
+func (f ArrayField) AtNumber(i int) NumberField {
+	return NumberFieldf("(?)["+strconv.Itoa(i)+"]", f)
+}
+
+// AtString returns a new StringField representing the i-th element of the
+// ArrayField i.e. '(A)[i]'. Array indexes start from 1.
+func (f ArrayField) AtString(i int) StringField {
+	return StringFieldf("(?)["+strconv.Itoa(i)+"]", f)
+}
+
+// AtBoolean returns a new BooleanField representing the i-th element of the
+// ArrayField i.e. '(A)[i]'. Array indexes start from 1.
+func (f ArrayField) AtBoolean(i int) BooleanField {
+	return BooleanFieldf("(?)["+strconv.Itoa(i)+"]", f)
+}
+
+// AtTime returns a new TimeField representing the i-th element of the
+// ArrayField i.e. '(A)[i]'. Array indexes start from 1.
+func (f ArrayField) AtTime(i int) TimeField {
+	return TimeFieldf("(?)["+strconv.Itoa(i)+"]", f)
+}
+
+// Length returns a new NumberField representing the length of the first
+// dimension of the ArrayField i.e. 'array_length(A, 1)'. Note that the length
+// of an empty array is NULL, use Cardinality if you want 0 instead.
+func (f ArrayField) Length() NumberField {
+	return NumberFieldf("array_length(?, 1)", f)
+}
+
+// Cardinality returns a new NumberField representing the total number of
+// elements in the ArrayField i.e. 'cardinality(A)'.
+func (f ArrayField) Cardinality() NumberField {
+	return NumberFieldf("cardinality(?)", f)
+}
+
+// Unnest returns a new UnnestTable that expands the ArrayField into a set of
+// rows i.e. 'unnest(A)', so that it can be used in a FROM or JOIN clause.
+func (f ArrayField) Unnest() UnnestTable {
+	return UnnestTable{
+		Alias: strings.ToLower(RandomString(8)),
+		Array: f,
 	}
 }
 
+// UnnestTable represents an array expanded into a set of rows i.e.
+// 'unnest(A) AS alias' (postgres only). Postgres names the single column of
+// the table after its alias, so the elements are accessed with the typed
+// accessors (see NumberField, StringField etc) rather than by column name.
+type UnnestTable struct {
+	Alias string
+	Array ArrayField
+}
+
+// ToSQL marshals an UnnestTable into an SQL query and args.
+func (ut UnnestTable) ToSQL() (string, []interface{}) {
+	return FormatPreprocessor("unnest(?)", []interface{}{ut.Array}, nil)
+}
+
+// As returns a new UnnestTable with the new alias.
+func (ut UnnestTable) As(alias string) UnnestTable {
+	ut.Alias = alias
+	return ut
+}
+
+// GetAlias implements the Table interface. It returns the Alias of the
+// UnnestTable.
+func (ut UnnestTable) GetAlias() string {
+	return ut.Alias
+}
+
+// GetName implements the Table interface. It always returns an empty string
+// because an UnnestTable does not have a name.
+func (ut UnnestTable) GetName() string {
+	return ""
+}
+
+// column returns the name of the single column of the UnnestTable. Postgres
+// names the column after the alias as it is written, which folds an unquoted
+// alias to lowercase, so the column name is the lowercased alias.
+func (ut UnnestTable) column() string {
+	return strings.ToLower(ut.Alias)
+}
+
+// NumberField returns a new NumberField representing the elements of the
+// UnnestTable.
+func (ut UnnestTable) NumberField() NumberField {
+	return NewNumberField(ut.column(), ut)
+}
+
+// StringField returns a new StringField representing the elements of the
+// UnnestTable.
+func (ut UnnestTable) StringField() StringField {
+	return NewStringField(ut.column(), ut)
+}
+
+// TimeField returns a new TimeField representing the elements of the
+// UnnestTable.
+func (ut UnnestTable) TimeField() TimeField {
+	return NewTimeField(ut.column(), ut)
+}
+
+// BooleanField returns a new BooleanField representing the elements of the
+// UnnestTable.
+func (ut UnnestTable) BooleanField() BooleanField {
+	return NewBooleanField(ut.column(), ut)
+}
+
+// JSONField returns a new JSONField representing the elements of the
+// UnnestTable.
+func (ut UnnestTable) JSONField() JSONField {
+	return NewJSONField(ut.column(), ut)
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of an ArrayField.
 func (f ArrayField) String() string {
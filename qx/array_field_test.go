@@ -2,6 +2,7 @@ package qx
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/matryer/is"
@@ -185,8 +186,67 @@ func TestArrayField_GameTheNumbers(t *testing.T) {
 	is.Equal("ARRAY['Trailers', 'Behind the Scenes'] <@ film.special_features", stringify(p))
 	p = f.Overlaps(Array([]string{"Trailers", "Behind the Scenes"}))
 	is.Equal("film.special_features && ARRAY['Trailers', 'Behind the Scenes']", stringify(p))
-	p = f.Concat(Array([]string{"Trailers", "Behind the Scenes"}))
-	is.Equal("film.special_features || ARRAY['Trailers', 'Behind the Scenes']", stringify(p))
+	query, args := f.Concat(Array([]string{"Trailers", "Behind the Scenes"})).ToSQLExclude(nil)
+	is.Equal("(film.special_features || ARRAY['Trailers', 'Behind the Scenes'])", MySQLInterpolateSQL(query, args...))
+	p = f.Contains(f.Concat(Array([]string{"Trailers"})))
+	is.Equal("film.special_features @> (film.special_features || ARRAY['Trailers'])", stringify(p))
 	// fmt.Stringer
 	fmt.Println(f)
 }
+
+func TestArrayField_Expressions(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	film := FILM()
+	f := film.SPECIAL_FEATURES
+	tests := []TT{
+		{"Concat", f.Concat(Array([]string{"Trailers"})).As("features"), "(film.special_features || ARRAY[?])", []interface{}{"Trailers"}},
+		{"Append", f.Append("Trailers"), "array_append(film.special_features, ?)", []interface{}{"Trailers"}},
+		{"Prepend", f.Prepend("Trailers"), "array_prepend(?, film.special_features)", []interface{}{"Trailers"}},
+		{"Remove", f.Remove(film.TITLE), "array_remove(film.special_features, film.title)", nil},
+		{"Slice", f.Slice(2, 3), "(film.special_features)[2:3]", nil},
+		{"At", f.At(1), "(film.special_features)[1]", nil},
+		{"AtNumber", Array([]int{1, 2}).AtNumber(2), "(ARRAY[?, ?])[2]", []interface{}{1, 2}},
+		{"AtString", f.AtString(1).Desc(), "(film.special_features)[1] DESC", nil},
+		{"AtBoolean", Array([]bool{true}).AtBoolean(1), "(ARRAY[?])[1]", []interface{}{true}},
+		{"AtTime", NewArrayField("dates", film).AtTime(1), "(film.dates)[1]", nil},
+		{"Length", f.Length(), "array_length(film.special_features, 1)", nil},
+		{"Cardinality", f.Append("Trailers").Cardinality(), "cardinality(array_append(film.special_features, ?))", []interface{}{"Trailers"}},
+		{"ArrayAgg", ArrayAgg(film.TITLE), "array_agg(film.title)", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.f.ToSQLExclude(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestArrayField_Unnest(t *testing.T) {
+	is := is.New(t)
+	f := FILM().SPECIAL_FEATURES
+	tbl := f.Unnest()
+	query, args := tbl.ToSQL()
+	is.Equal("unnest(film.special_features)", query)
+	is.Equal(0, len(args))
+	is.True(f.Unnest().GetAlias() != tbl.GetAlias())
+	is.Equal(strings.ToLower(tbl.GetAlias()), tbl.GetAlias())
+	query, _ = tbl.StringField().ToSQLExclude(nil)
+	is.Equal(tbl.GetAlias()+"."+tbl.GetAlias(), query)
+	query, _ = f.Unnest().As("Feature").StringField().ToSQLExclude(nil)
+	is.Equal("Feature.feature", query)
+	feature := f.Unnest().As("feature").StringField()
+	query, _ = feature.ToSQLExclude(nil)
+	is.Equal("feature.feature", query)
+	query, args = feature.EqAny(Array([]string{"Trailers", "Commentaries"})).ToSQLExclude(nil)
+	is.Equal("feature.feature = ANY (ARRAY[?, ?])", query)
+	is.Equal([]interface{}{"Trailers", "Commentaries"}, args)
+}
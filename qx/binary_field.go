@@ -26,18 +26,24 @@ func (f BinaryField) ToSQLExclude(excludeTableQualifiers []string) (string, []in
 	var tableQualifier string
 	if f.table != nil {
 		if f.table.GetAlias() != "" {
-			tableQualifier = f.table.GetAlias() + "."
+			tableQualifier = f.table.GetAlias()
 		} else if f.table.GetName() != "" {
-			tableQualifier = f.table.GetName() + "."
+			tableQualifier = f.table.GetName()
 		}
 	}
 	for i := range excludeTableQualifiers {
-		if tableQualifier == excludeTableQualifiers[i]+"." {
+		if tableQualifier == excludeTableQualifiers[i] {
 			tableQualifier = ""
 			break
 		}
 	}
-	columnName := tableQualifier + f.name
+	columnName := QuoteIdentifier(f.name)
+	if tableQualifier != "" {
+		if f.table.GetAlias() == "" {
+			tableQualifier = QuoteIdentifier(tableQualifier)
+		}
+		columnName = tableQualifier + "." + columnName
+	}
 	return columnName, nil
 }
 
@@ -1,17 +1,27 @@
 package qx
 
-// BooleanField either represents a boolean column or a literal bool value.
+// BooleanField either represents a boolean column, a boolean expression or a
+// literal bool value.
 type BooleanField struct {
 	// BooleanField will be one of the following:
 
-	// 1) Literal bool value
+	// 1) Boolean expression
+	// Examples of boolean expressions:
+	// | query                    | args |
+	// |--------------------------|------|
+	// | users.uid > ?            | 5    |
+	// | NOT users.is_active      |      |
+	format *string
+	values []interface{}
+
+	// 2) Literal bool value
 	// Examples of literal bool values:
 	// | query | args |
 	// |-------|------|
 	// | ?     | true |
 	value *bool
 
-	// 2) Boolean column
+	// 3) Boolean column
 	// Examples of boolean columns:
 	// | query            | args |
 	// |------------------|------|
@@ -29,27 +39,44 @@ type BooleanField struct {
 // appears in the excludeTableQualifiers list, the output column name will not
 // be table qualified.
 func (f BooleanField) ToSQLExclude(excludeTableQualifiers []string) (string, []interface{}) {
-	// 1) Literal bool value
+	// 1) Boolean expression
+	if f.format != nil {
+		return CustomField{
+			Alias:        f.alias,
+			Format:       *f.format,
+			Values:       f.values,
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
+		}.ToSQLExclude(excludeTableQualifiers)
+	}
+
+	// 2) Literal bool value
 	if f.value != nil {
 		return "?", []interface{}{*f.value}
 	}
 
-	// 2) Boolean column
+	// 3) Boolean column
 	var tableQualifier string
 	if f.table != nil {
 		if f.table.GetAlias() != "" {
-			tableQualifier = f.table.GetAlias() + "."
+			tableQualifier = f.table.GetAlias()
 		} else if f.table.GetName() != "" {
-			tableQualifier = f.table.GetName() + "."
+			tableQualifier = f.table.GetName()
 		}
 	}
 	for i := range excludeTableQualifiers {
-		if tableQualifier == excludeTableQualifiers[i]+"." {
+		if tableQualifier == excludeTableQualifiers[i] {
 			tableQualifier = ""
 			break
 		}
 	}
-	columnName := tableQualifier + f.name
+	columnName := QuoteIdentifier(f.name)
+	if tableQualifier != "" {
+		if f.table.GetAlias() == "" {
+			tableQualifier = QuoteIdentifier(tableQualifier)
+		}
+		columnName = tableQualifier + "." + columnName
+	}
 	if f.descending != nil {
 		if *f.descending {
 			columnName = columnName + " DESC"
@@ -75,6 +102,18 @@ func NewBooleanField(name string, table Table) BooleanField {
 	}
 }
 
+// BooleanFieldf follows a printf-like syntax that takes in multiple values and
+// returns a BooleanField formatted according to an arbitrary format string. The
+// only recognized format specifier is the ? question mark. E.g.
+// BooleanFieldf("NOT ?", field). Values are interpolated as described in
+// FormatPreprocessor.
+func BooleanFieldf(format string, values ...interface{}) BooleanField {
+	return BooleanField{
+		format: &format,
+		values: values,
+	}
+}
+
 // Bool returns a new Boolean Field representing a literal bool value.
 func Bool(b bool) BooleanField {
 	return BooleanField{
@@ -0,0 +1,238 @@
+package qx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CaseExpr represents an SQL CASE expression. If Operand is set it is a simple
+// CASE expression i.e. 'CASE operand WHEN value THEN result ... END',
+// otherwise it is a searched CASE expression i.e. 'CASE WHEN predicate THEN
+// result ... END'.
+//
+// CaseExpr is itself a Field, but it can also be converted into a typed Field
+// (NumberField, StringField etc) based on the types of its results so that it
+// can be used in comparison predicates, see Typed.
+type CaseExpr struct {
+	Alias   string
+	Operand Field
+	Whens   []CaseWhenThen
+	Default interface{}
+}
+
+// CaseWhenThen represents a 'WHEN when THEN then' branch of a CaseExpr.
+type CaseWhenThen struct {
+	When interface{}
+	Then interface{}
+}
+
+// Case returns a new simple CaseExpr that compares the field against the
+// values of its WHEN branches.
+func Case(field Field) CaseExpr {
+	return CaseExpr{
+		Operand: field,
+	}
+}
+
+// CaseWhen returns a new searched CaseExpr, starting with the branch 'WHEN
+// predicate THEN result'.
+func CaseWhen(predicate Predicate, result interface{}) CaseExpr {
+	return CaseExpr{
+		Whens: []CaseWhenThen{{When: predicate, Then: result}},
+	}
+}
+
+// ToSQLExclude marshals a CaseExpr into an SQL query and args. The list of
+// table qualifiers to be excluded is propagated down to the Operand and the
+// values of every branch.
+//
+// It panics if the CaseExpr has no WHEN branches, since 'CASE END' is not
+// valid SQL.
+func (c CaseExpr) ToSQLExclude(excludeTableQualifiers []string) (string, []interface{}) {
+	if len(c.Whens) == 0 {
+		panic("CASE expression has no WHEN branches")
+	}
+	format := &strings.Builder{}
+	var values []interface{}
+	format.WriteString("CASE")
+	if c.Operand != nil {
+		format.WriteString(" ?")
+		values = append(values, c.Operand)
+	}
+	for i := range c.Whens {
+		format.WriteString(" WHEN ? THEN ?")
+		values = append(values, c.Whens[i].When, c.Whens[i].Then)
+	}
+	if c.Default != nil {
+		format.WriteString(" ELSE ?")
+		values = append(values, c.Default)
+	}
+	format.WriteString(" END")
+	return FormatPreprocessor(format.String(), values, excludeTableQualifiers)
+}
+
+// When returns a new CaseExpr with the branch 'WHEN when THEN result' added.
+// For a simple CaseExpr when is a value to compare the Operand against, for a
+// searched CaseExpr when should be a Predicate.
+func (c CaseExpr) When(when interface{}, result interface{}) CaseExpr {
+	whens := make([]CaseWhenThen, len(c.Whens), len(c.Whens)+1)
+	copy(whens, c.Whens)
+	c.Whens = append(whens, CaseWhenThen{When: when, Then: result})
+	return c
+}
+
+// Else returns a new CaseExpr with the 'ELSE result' branch.
+func (c CaseExpr) Else(result interface{}) CaseExpr {
+	c.Default = result
+	return c
+}
+
+// As returns a new CaseExpr with the new alias i.e. 'CASE ... END AS alias'.
+func (c CaseExpr) As(alias string) CaseExpr {
+	c.Alias = alias
+	return c
+}
+
+// GetAlias implements the Field interface. It returns the Alias of the
+// CaseExpr.
+func (c CaseExpr) GetAlias() string {
+	return c.Alias
+}
+
+// GetName implements the Field interface. It always returns an empty string
+// because CaseExprs do not have names.
+func (c CaseExpr) GetName() string {
+	return ""
+}
+
+const (
+	caseNumber  = "number"
+	caseString  = "string"
+	caseTime    = "time"
+	caseBoolean = "boolean"
+)
+
+// resultKind returns the type that the THEN and ELSE results of the CaseExpr
+// agree on: "number" if every result is a NumberField or a Go number, "string"
+// if every result is a StringField or a Go string and so on. NULL results are
+// ignored. An empty string is returned if no result has a known type, and an
+// error if the results have different types.
+func (c CaseExpr) resultKind() (string, error) {
+	results := make([]interface{}, 0, len(c.Whens)+1)
+	for i := range c.Whens {
+		results = append(results, c.Whens[i].Then)
+	}
+	results = append(results, c.Default)
+	var kind string
+	for _, result := range results {
+		k := caseResultKind(result)
+		if k == "" {
+			continue
+		}
+		if kind != "" && kind != k {
+			return "", fmt.Errorf("CASE expression mixes %s and %s results", kind, k)
+		}
+		kind = k
+	}
+	return kind, nil
+}
+
+// caseResultKind returns the type of a result of a CaseExpr, or an empty
+// string if the type is not known.
+func caseResultKind(result interface{}) string {
+	switch result.(type) {
+	case nil:
+		return ""
+	case NumberField:
+		return caseNumber
+	case StringField:
+		return caseString
+	case TimeField, time.Time:
+		return caseTime
+	case BooleanField:
+		return caseBoolean
+	}
+	switch reflect.TypeOf(result).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return caseNumber
+	case reflect.String:
+		return caseString
+	case reflect.Bool:
+		return caseBoolean
+	}
+	return ""
+}
+
+// mustBe panics if the results of the CaseExpr are not of the kind.
+func (c CaseExpr) mustBe(kind string) {
+	k, err := c.resultKind()
+	if err != nil {
+		panic(err)
+	}
+	if k != "" && k != kind {
+		panic(fmt.Errorf("CASE expression has %s results, not %s results", k, kind))
+	}
+}
+
+// Typed returns the CaseExpr as a typed Field based on the types of its THEN
+// and ELSE results: a NumberField if all results are NumberFields or Go
+// numbers, a StringField if all results are StringFields or Go strings and so
+// on. NULL results are ignored. If no result has a known type the CaseExpr
+// itself is returned. It panics if the results have different types.
+func (c CaseExpr) Typed() Field {
+	kind, err := c.resultKind()
+	if err != nil {
+		panic(err)
+	}
+	switch kind {
+	case caseNumber:
+		return c.NumberField()
+	case caseString:
+		return c.StringField()
+	case caseTime:
+		return c.TimeField()
+	case caseBoolean:
+		return c.BooleanField()
+	}
+	return c
+}
+
+// NumberField returns the CaseExpr as a NumberField. It panics if the results of
+// the CaseExpr are not all of that type (NULL results are ignored).
+func (c CaseExpr) NumberField() NumberField {
+	c.mustBe(caseNumber)
+	f := NumberFieldf("?", c)
+	f.alias = c.Alias
+	return f
+}
+
+// StringField returns the CaseExpr as a StringField. It panics if the results of
+// the CaseExpr are not all of that type (NULL results are ignored).
+func (c CaseExpr) StringField() StringField {
+	c.mustBe(caseString)
+	f := StringFieldf("?", c)
+	f.alias = c.Alias
+	return f
+}
+
+// TimeField returns the CaseExpr as a TimeField. It panics if the results of
+// the CaseExpr are not all of that type (NULL results are ignored).
+func (c CaseExpr) TimeField() TimeField {
+	c.mustBe(caseTime)
+	f := TimeFieldf("?", c)
+	f.alias = c.Alias
+	return f
+}
+
+// BooleanField returns the CaseExpr as a BooleanField. It panics if the results of
+// the CaseExpr are not all of that type (NULL results are ignored).
+func (c CaseExpr) BooleanField() BooleanField {
+	c.mustBe(caseBoolean)
+	f := BooleanFieldf("?", c)
+	f.alias = c.Alias
+	return f
+}
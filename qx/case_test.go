@@ -0,0 +1,109 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCaseExpr(t *testing.T) {
+	type TT struct {
+		DESCRIPTION            string
+		f                      Field
+		excludeTableQualifiers []string
+		wantQuery              string
+		wantArgs               []interface{}
+	}
+	f := FILM()
+	tests := []TT{
+		{
+			"simple case",
+			Case(f.RATING).When("G", "General").When("PG", "Parental Guidance").Else("Restricted"),
+			nil,
+			"CASE film.rating WHEN ? THEN ? WHEN ? THEN ? ELSE ? END",
+			[]interface{}{"G", "General", "PG", "Parental Guidance", "Restricted"},
+		},
+		{
+			"searched case",
+			CaseWhen(f.LENGTH.LtInt(60), f.LENGTH).When(f.LENGTH.LtInt(120), Int(120)),
+			nil,
+			"CASE WHEN film.length < ? THEN film.length WHEN film.length < ? THEN ? END",
+			[]interface{}{60, 120, 120},
+		},
+		{
+			"case respects excludeTableQualifiers",
+			CaseWhen(f.LENGTH.GtInt(60), f.TITLE).Else(f.DESCRIPTION),
+			[]string{"film"},
+			"CASE WHEN length > ? THEN title ELSE description END",
+			[]interface{}{60},
+		},
+		{
+			"NumberField",
+			CaseWhen(f.RENTAL_RATE.GtFloat64(2), f.RENTAL_RATE).Else(Float64(2)).NumberField().Desc(),
+			nil,
+			"CASE WHEN film.rental_rate > ? THEN film.rental_rate ELSE ? END DESC",
+			[]interface{}{2.0, 2.0},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.f.ToSQLExclude(tt.excludeTableQualifiers)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestCaseExpr_Typed(t *testing.T) {
+	is := is.New(t)
+	f := FILM()
+	_, ok := CaseWhen(f.LENGTH.GtInt(60), int32(1)).Else(f.LENGTH).Typed().(NumberField)
+	is.True(ok)
+	_, ok = CaseWhen(f.LENGTH.GtInt(60), uint(1)).Else(float32(0.5)).Typed().(NumberField)
+	is.True(ok)
+	_, ok = Case(f.RATING).When("G", "kids").When("R", nil).Typed().(StringField)
+	is.True(ok)
+	_, ok = Case(f.RATING).When("G", true).Else(false).Typed().(BooleanField)
+	is.True(ok)
+	_, ok = Case(f.RATING).When("G", f.LAST_UPDATE).Typed().(TimeField)
+	is.True(ok)
+	_, ok = Case(f.RATING).When("G", nil).Typed().(CaseExpr)
+	is.True(ok)
+	gotQuery, gotArgs := CaseWhen(f.LENGTH.GtInt(60), int32(1)).Else(f.LENGTH).NumberField().GtInt(0).ToSQLExclude(nil)
+	is.Equal("CASE WHEN film.length > ? THEN ? ELSE film.length END > ?", gotQuery)
+	is.Equal([]interface{}{60, int32(1), 0}, gotArgs)
+	gotQuery, gotArgs = Case(f.RATING).When("G", "kids").Else("adults").StringField().EqString("kids").ToSQLExclude(nil)
+	is.Equal("CASE film.rating WHEN ? THEN ? ELSE ? END = ?", gotQuery)
+	is.Equal([]interface{}{"G", "kids", "adults", "kids"}, gotArgs)
+	gotQuery, _ = Case(f.RATING).When("G", true).Else(false).BooleanField().Not().ToSQLExclude(nil)
+	is.Equal("NOT CASE film.rating WHEN ? THEN ? ELSE ? END", gotQuery)
+	is.Equal("long", CaseWhen(f.LENGTH.GtInt(60), 1).As("long").Typed().GetAlias())
+	is.Equal("updated", CaseWhen(f.LENGTH.GtInt(60), f.LAST_UPDATE).As("updated").TimeField().GetAlias())
+}
+
+func TestCaseExpr_Panics(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           func()
+	}
+	f := FILM()
+	tests := []TT{
+		{"mixed results", func() { Case(f.RATING).When("G", "kids").Else(1).Typed() }},
+		{"wrong accessor", func() { Case(f.RATING).When("G", "kids").NumberField() }},
+		{"no WHEN branches", func() { Case(f.RATING).ToSQLExclude(nil) }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			defer func() {
+				is.True(recover() != nil)
+			}()
+			tt.f()
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package qx
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// CompoundBranch is a branch of a compound query i.e. one of the queries in
+// 'query1 UNION query2 UNION query3 ...'.
+type CompoundBranch struct {
+	Query Query
+	// Parenthesize is true if the branch has clauses of its own (such as WITH,
+	// ORDER BY, LIMIT or OFFSET) that would otherwise be applied to the
+	// combined result.
+	Parenthesize bool
+	// Tables are the tables that the branch selects from.
+	Tables []Table
+	// Locked is true if the branch has row locking clauses.
+	Locked bool
+	// NoFields is true if the branch does not select any fields.
+	NoFields bool
+}
+
+// CompoundBranches is a list of CompoundBranch.
+type CompoundBranches []CompoundBranch
+
+// WriteSQL will write the branches joined by the operator into the buffer and
+// args. Branches that need to be parenthesized are wrapped with
+// prependWith and appendWith e.g. "(" and ")".
+func (branches CompoundBranches) WriteSQL(buf *strings.Builder, args *[]interface{}, operator VariadicQueryOperator, prependWith, appendWith string) (written bool) {
+	if operator == "" {
+		operator = QueryUnion
+	}
+	for i := range branches {
+		if branches[i].Query == nil {
+			continue
+		}
+		query, queryArgs := branches[i].Query.NestThis().ToSQL()
+		if query == "" {
+			continue
+		}
+		if written {
+			buf.WriteString(" " + string(operator) + " ")
+		}
+		if branches[i].Parenthesize {
+			query = prependWith + query + appendWith
+		}
+		buf.WriteString(query)
+		*args = append(*args, queryArgs...)
+		written = true
+	}
+	return written
+}
+
+// TableQualifiers returns the names and aliases of the tables selected from
+// by the branches. The ORDER BY of a compound query can only refer to the
+// output columns of the combined result, so these table qualifiers should be
+// excluded from its fields.
+func (branches CompoundBranches) TableQualifiers() []string {
+	var qualifiers []string
+	for i := range branches {
+		for _, table := range branches[i].Tables {
+			if table == nil {
+				continue
+			}
+			if alias := table.GetAlias(); alias != "" {
+				qualifiers = append(qualifiers, alias)
+			}
+			if name := table.GetName(); name != "" {
+				qualifiers = append(qualifiers, name)
+			}
+		}
+	}
+	return qualifiers
+}
+
+// Check returns an error if any of the branches cannot be part of a compound
+// query. Row locking clauses are not allowed in a compound query, and every
+// branch other than the first must select its own fields because a Mapper's
+// fields are only valid for the tables of the first branch.
+func (branches CompoundBranches) Check() error {
+	for i := range branches {
+		if branches[i].Locked {
+			return errors.New("row locking clauses are not allowed in UNION, INTERSECT or EXCEPT")
+		}
+		if i > 0 && branches[i].NoFields {
+			return errors.New("compound query branch " + strconv.Itoa(i+1) + " does not select any fields")
+		}
+	}
+	return nil
+}
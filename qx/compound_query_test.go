@@ -0,0 +1,31 @@
+package qx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCompoundBranches(t *testing.T) {
+	is := is.New(t)
+	film := FILM()
+	branches := CompoundBranches{
+		{Query: CustomQuery{Format: "SELECT film_id FROM film WHERE rating = ?", Values: []interface{}{"G"}}, Tables: []Table{film}},
+		{Query: CustomQuery{Format: "SELECT film_id FROM film LIMIT ?", Values: []interface{}{5}}, Parenthesize: true},
+		{Query: nil},
+	}
+	buf := &strings.Builder{}
+	var args []interface{}
+	is.True(branches.WriteSQL(buf, &args, QueryUnionAll, "(", ")"))
+	is.Equal("SELECT film_id FROM film WHERE rating = ? UNION ALL (SELECT film_id FROM film LIMIT ?)", buf.String())
+	is.Equal([]interface{}{"G", 5}, args)
+	is.Equal([]string{"film"}, branches.TableQualifiers())
+	is.NoErr(branches.Check())
+	branches[0].NoFields = true // only the first branch may leave its fields to the Mapper
+	is.NoErr(branches.Check())
+	branches[1].NoFields = true
+	is.Equal("compound query branch 2 does not select any fields", branches.Check().Error())
+	branches[0].Locked = true
+	is.Equal("row locking clauses are not allowed in UNION, INTERSECT or EXCEPT", branches.Check().Error())
+}
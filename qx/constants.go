@@ -20,4 +20,78 @@ const (
 	JoinTypeRight   JoinType = "RIGHT JOIN"
 	JoinTypeFull    JoinType = "FULL JOIN"
 	JoinTypeCross   JoinType = "CROSS JOIN"
+	JoinTypeNatural JoinType = "NATURAL JOIN"
+)
+
+// FrameMode represents the various modes of an SQL window frame.
+type FrameMode string
+
+// FrameModes
+const (
+	FrameModeRows   FrameMode = "ROWS"
+	FrameModeRange  FrameMode = "RANGE"
+	FrameModeGroups FrameMode = "GROUPS" // postgres and sqlite only
+)
+
+// FrameBounds
+const (
+	FrameUnboundedPreceding = "UNBOUNDED PRECEDING"
+	FrameCurrentRow         = "CURRENT ROW"
+	FrameUnboundedFollowing = "UNBOUNDED FOLLOWING"
+)
+
+// CTEMaterialization represents the materialization hints of a CTE.
+type CTEMaterialization string
+
+// CTEMaterializations
+const (
+	CTEMaterialized    CTEMaterialization = "MATERIALIZED"     // postgres 12+ only
+	CTENotMaterialized CTEMaterialization = "NOT MATERIALIZED" // postgres 12+ only
+)
+
+// LockStrength represents the various strengths of an SQL row locking clause.
+type LockStrength string
+
+// LockStrengths
+const (
+	LockForUpdate      LockStrength = "FOR UPDATE"
+	LockForNoKeyUpdate LockStrength = "FOR NO KEY UPDATE" // postgres only
+	LockForShare       LockStrength = "FOR SHARE"
+	LockForKeyShare    LockStrength = "FOR KEY SHARE" // postgres only
+)
+
+// LockWaitPolicy represents what a row locking clause should do when a row
+// is already locked.
+type LockWaitPolicy string
+
+// LockWaitPolicies
+const (
+	LockNoWait     LockWaitPolicy = "NOWAIT"
+	LockSkipLocked LockWaitPolicy = "SKIP LOCKED"
+)
+
+// DatePart represents the various units of a date/time value, as used by
+// TimeField.DateTrunc and TimeField.Extract.
+type DatePart string
+
+// DateParts
+const (
+	DatePartMicroseconds DatePart = "microseconds"
+	DatePartMilliseconds DatePart = "milliseconds"
+	DatePartSecond       DatePart = "second"
+	DatePartMinute       DatePart = "minute"
+	DatePartHour         DatePart = "hour"
+	DatePartDay          DatePart = "day"
+	DatePartWeek         DatePart = "week"
+	DatePartMonth        DatePart = "month"
+	DatePartQuarter      DatePart = "quarter"
+	DatePartYear         DatePart = "year"
+	DatePartDecade       DatePart = "decade"     // postgres only
+	DatePartCentury      DatePart = "century"    // postgres only
+	DatePartMillennium   DatePart = "millennium" // postgres only
+	DatePartDow          DatePart = "dow"        // Extract only, postgres only
+	DatePartDoy          DatePart = "doy"        // Extract only, postgres only
+	DatePartEpoch        DatePart = "epoch"      // Extract only, postgres only
+	DatePartIsoDow       DatePart = "isodow"     // Extract only, postgres only
+	DatePartIsoYear      DatePart = "isoyear"    // Extract only, postgres only
 )
@@ -8,11 +8,21 @@ import (
 type CTE struct {
 	Name  string
 	Query Query
+	// Recursive indicates that the CTE refers to itself. If any CTE in a WITH
+	// clause is recursive, the clause is written as WITH RECURSIVE.
+	Recursive bool
+	// Columns is the optional list of column names of the CTE i.e.
+	// 'name(col1, col2) AS (query)'.
+	Columns []string
+	// Materialization is the optional MATERIALIZED or NOT MATERIALIZED hint
+	// of the CTE (postgres 12+ only).
+	Materialization CTEMaterialization
 }
 
-// ToSQL simply returns the name of the CTE.
+// ToSQL simply returns the name of the CTE, quoted the same way as its
+// columns if it needs to be.
 func (cte CTE) ToSQL() (string, []interface{}) {
-	return cte.Name, nil
+	return QuoteIdentifier(cte.Name), nil
 }
 
 func NewCTE(name string, query Query) CTE {
@@ -22,6 +32,48 @@ func NewCTE(name string, query Query) CTE {
 	}
 }
 
+// RecursiveCTE returns a new recursive CTE with the given column names. Its
+// query is usually set afterwards with Union or UnionAll, so that the
+// recursive term can refer to the CTE's own columns e.g.
+//
+//	tree := RecursiveCTE("tree", "id", "depth")
+//	tree = tree.UnionAll(anchor, Select(tree.NumberField("depth")).From(tree))
+func RecursiveCTE(name string, columns ...string) CTE {
+	return CTE{
+		Name:      name,
+		Recursive: true,
+		Columns:   columns,
+	}
+}
+
+// Union returns a new CTE whose query is the UNION of the queries, usually
+// the anchor (non-recursive) term followed by the recursive term.
+func (cte CTE) Union(queries ...Query) CTE {
+	cte.Query = VariadicQuery{Operator: QueryUnion, Queries: queries}
+	return cte
+}
+
+// UnionAll returns a new CTE whose query is the UNION ALL of the queries,
+// usually the anchor (non-recursive) term followed by the recursive term.
+func (cte CTE) UnionAll(queries ...Query) CTE {
+	cte.Query = VariadicQuery{Operator: QueryUnionAll, Queries: queries}
+	return cte
+}
+
+// Materialized returns a new CTE with the MATERIALIZED hint (postgres 12+
+// only).
+func (cte CTE) Materialized() CTE {
+	cte.Materialization = CTEMaterialized
+	return cte
+}
+
+// NotMaterialized returns a new CTE with the NOT MATERIALIZED hint (postgres
+// 12+ only).
+func (cte CTE) NotMaterialized() CTE {
+	cte.Materialization = CTENotMaterialized
+	return cte
+}
+
 // GetAlias implements the Table interface. It always returns an empty string,
 // because CTEs do not have aliases (only AliasedCTEs do).
 func (cte CTE) GetAlias() string {
@@ -38,10 +90,43 @@ func (cte CTE) GetName() string {
 // its own name to the fieldName.
 func (cte CTE) Get(fieldName string) CustomField {
 	return CustomField{
-		Format: cte.Name + "." + fieldName,
+		Format: QuoteIdentifier(cte.Name) + "." + fieldName,
 	}
 }
 
+// NumberField returns a NumberField from the CTE identified by fieldName. Like
+// Get, no checks are done to see if the fieldName really exists in the CTE.
+func (cte CTE) NumberField(fieldName string) NumberField {
+	return NewNumberField(fieldName, cte.qualifier())
+}
+
+// StringField returns a StringField from the CTE identified by fieldName.
+// Like Get, no checks are done to see if the fieldName really exists in the
+// CTE.
+func (cte CTE) StringField(fieldName string) StringField {
+	return NewStringField(fieldName, cte.qualifier())
+}
+
+// TimeField returns a TimeField from the CTE identified by fieldName. Like
+// Get, no checks are done to see if the fieldName really exists in the CTE.
+func (cte CTE) TimeField(fieldName string) TimeField {
+	return NewTimeField(fieldName, cte.qualifier())
+}
+
+// BooleanField returns a BooleanField from the CTE identified by fieldName.
+// Like Get, no checks are done to see if the fieldName really exists in the
+// CTE.
+func (cte CTE) BooleanField(fieldName string) BooleanField {
+	return NewBooleanField(fieldName, cte.qualifier())
+}
+
+// qualifier returns the Table that fields of the CTE should be qualified
+// with. A CTE has no alias, so the CTE name is quoted the same way the WITH
+// clause and Get write it.
+func (cte CTE) qualifier() Table {
+	return CTE{Name: cte.Name}
+}
+
 // CTEs represents a list of CTEs
 type CTEs []CTE
 
@@ -51,20 +136,48 @@ type CTEs []CTE
 func (ctes CTEs) WriteSQL(buf *strings.Builder, args *[]interface{}) (written bool) {
 	var ctesQueries []string
 	var ctesArgs []interface{}
+	var recursive bool
 	for i := range ctes {
 		if ctes[i].Query == nil {
 			continue
 		}
-		cteQuery, cteArgs := ctes[i].Query.NestThis().ToSQL()
+		var cteQuery string
+		var cteArgs []interface{}
+		if q, ok := ctes[i].Query.(VariadicQuery); ok {
+			// A nested VariadicQuery wraps itself in brackets, which the CTE
+			// already does
+			cteQuery, cteArgs = q.ToSQL()
+		} else {
+			cteQuery, cteArgs = ctes[i].Query.NestThis().ToSQL()
+		}
 		if cteQuery == "" {
 			continue
 		}
-		cteQuery = ctes[i].Name + " AS (" + cteQuery + ")"
+		name := QuoteIdentifier(ctes[i].Name)
+		if len(ctes[i].Columns) > 0 {
+			columns := make([]string, len(ctes[i].Columns))
+			for j := range ctes[i].Columns {
+				columns[j] = QuoteIdentifier(ctes[i].Columns[j])
+			}
+			name = name + "(" + strings.Join(columns, ", ") + ")"
+		}
+		if ctes[i].Materialization != "" {
+			cteQuery = name + " AS " + string(ctes[i].Materialization) + " (" + cteQuery + ")"
+		} else {
+			cteQuery = name + " AS (" + cteQuery + ")"
+		}
+		if ctes[i].Recursive {
+			recursive = true
+		}
 		ctesQueries = append(ctesQueries, cteQuery)
 		ctesArgs = append(ctesArgs, cteArgs...)
 	}
 	if len(ctesQueries) > 0 {
-		buf.WriteString("WITH " + strings.Join(ctesQueries, ", "))
+		if recursive {
+			buf.WriteString("WITH RECURSIVE " + strings.Join(ctesQueries, ", "))
+		} else {
+			buf.WriteString("WITH " + strings.Join(ctesQueries, ", "))
+		}
 		*args = append(*args, ctesArgs...)
 		return true
 	}
@@ -90,7 +203,7 @@ func (cte CTE) As(alias string) AliasedCTE {
 // There is no need to provide the alias, as the caller of ToSQL() should be
 // responsible for calling GetAlias() as well.
 func (cte AliasedCTE) ToSQL() (string, []interface{}) {
-	return cte.Name, nil
+	return QuoteIdentifier(cte.Name), nil
 }
 
 // GetAlias implements the Table interface. It returns the alias of the
@@ -113,3 +226,31 @@ func (cte AliasedCTE) Get(fieldName string) CustomField {
 		Format: cte.Alias + "." + fieldName,
 	}
 }
+
+// NumberField returns a NumberField from the AliasedCTE identified by
+// fieldName. Like Get, no checks are done to see if the fieldName really
+// exists in the AliasedCTE.
+func (cte AliasedCTE) NumberField(fieldName string) NumberField {
+	return NewNumberField(fieldName, cte)
+}
+
+// StringField returns a StringField from the AliasedCTE identified by
+// fieldName. Like Get, no checks are done to see if the fieldName really
+// exists in the AliasedCTE.
+func (cte AliasedCTE) StringField(fieldName string) StringField {
+	return NewStringField(fieldName, cte)
+}
+
+// TimeField returns a TimeField from the AliasedCTE identified by fieldName.
+// Like Get, no checks are done to see if the fieldName really exists in the
+// AliasedCTE.
+func (cte AliasedCTE) TimeField(fieldName string) TimeField {
+	return NewTimeField(fieldName, cte)
+}
+
+// BooleanField returns a BooleanField from the AliasedCTE identified by
+// fieldName. Like Get, no checks are done to see if the fieldName really
+// exists in the AliasedCTE.
+func (cte AliasedCTE) BooleanField(fieldName string) BooleanField {
+	return NewBooleanField(fieldName, cte)
+}
@@ -36,6 +36,28 @@ func TestCTE_WriteSQL(t *testing.T) {
 				", cte3 AS (There can never be too many cherries on an ice cream sundae.)"
 			return TT{DESCRIPTION, ctes, true, wantQuery, nil}
 		}(),
+		func() TT {
+			DESCRIPTION := "recursive with columns"
+			ctes := CTEs{
+				CTE{Name: "cte0", Query: CustomQuery{Format: "SELECT ?", Values: []interface{}{1}}},
+				RecursiveCTE("Series", "n", "Total").UnionAll(
+					CustomQuery{Format: "SELECT ?, ?", Values: []interface{}{1, 1}},
+					CustomQuery{Format: `SELECT n + 1, "Total" * n FROM "Series" WHERE n < ?`, Values: []interface{}{10}},
+				),
+			}
+			wantQuery := `WITH RECURSIVE cte0 AS (SELECT ?)` +
+				`, "Series"(n, "Total") AS (SELECT ?, ? UNION ALL SELECT n + 1, "Total" * n FROM "Series" WHERE n < ?)`
+			return TT{DESCRIPTION, ctes, true, wantQuery, []interface{}{1, 1, 1, 10}}
+		}(),
+		func() TT {
+			DESCRIPTION := "materialized"
+			ctes := CTEs{
+				NewCTE("cte0", CustomQuery{Format: "SELECT 1"}).Materialized(),
+				NewCTE("cte1", CustomQuery{Format: "SELECT 2"}).NotMaterialized(),
+			}
+			wantQuery := "WITH cte0 AS MATERIALIZED (SELECT 1), cte1 AS NOT MATERIALIZED (SELECT 2)"
+			return TT{DESCRIPTION, ctes, true, wantQuery, nil}
+		}(),
 		func() TT {
 			DESCRIPTION := "nothing"
 			ctes := CTEs{}
@@ -77,3 +99,21 @@ func TestCTE_GameTheNumbers(t *testing.T) {
 	query, _ = aliasedcte.Get("xkcd").ToSQLExclude(nil)
 	is.Equal("other_cte.xkcd", query)
 }
+
+func TestCTE_TypedFields(t *testing.T) {
+	is := is.New(t)
+	var query string
+	cte := RecursiveCTE("Tree", "id", "depth")
+	query, _ = cte.NumberField("depth").ToSQLExclude(nil)
+	is.Equal(`"Tree".depth`, query)
+	query, _ = cte.StringField("Name").ToSQLExclude(nil)
+	is.Equal(`"Tree"."Name"`, query)
+	query, _ = cte.Get("depth").ToSQLExclude(nil)
+	is.Equal(`"Tree".depth`, query)
+	query, _ = cte.ToSQL()
+	is.Equal(`"Tree"`, query)
+	query, _ = cte.TimeField("created_at").ToSQLExclude([]string{"Tree"})
+	is.Equal("created_at", query)
+	query, _ = cte.As("t").BooleanField("is_leaf").ToSQLExclude(nil)
+	is.Equal("t.is_leaf", query)
+}
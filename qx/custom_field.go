@@ -151,6 +151,22 @@ func (f CustomField) In(v interface{}) Predicate {
 	}
 }
 
+// NotIn returns an 'A NOT IN (B)' Predicate, where B can be anything.
+func (f CustomField) NotIn(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? NOT IN (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// InQuery returns an 'A IN (subquery)' Predicate.
+func (f CustomField) InQuery(subquery Query) Predicate {
+	return CustomPredicate{
+		Format: "? IN (?)",
+		Values: []interface{}{f, subquery},
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a CustomField.
 func (f CustomField) String() string {
@@ -43,8 +43,11 @@ func (tbl CustomTable) GetName() string {
 // placeholder ?, ?, ? -> $1, $2, $3 etc rebinding.
 type CustomQuery struct {
 	// Postgres flag determines whether we need to rebind ?, ?, ? to $1, $2,
-	// $3.
+	// $3. It is ignored if Dialect is set.
 	Postgres bool
+	// Dialect determines how the query's placeholders and identifiers are
+	// rebound (see Rebind).
+	Dialect Dialect
 
 	Nested bool
 	Alias  string
@@ -55,12 +58,24 @@ type CustomQuery struct {
 // ToSQL marshals a CustomQuery into an SQL query.
 func (q CustomQuery) ToSQL() (string, []interface{}) {
 	query, args := FormatPreprocessor(q.Format, q.Values, nil)
-	if !q.Nested && q.Postgres {
-		query = MySQLToPostgresPlaceholders(query)
+	switch {
+	case q.Nested:
+		// no-op
+	case q.Dialect != nil:
+		query = Rebind(q.Dialect, query)
+	case q.Postgres:
+		query = Rebind(Postgres, query)
 	}
 	return query, args
 }
 
+// ToSQLDialect marshals a CustomQuery into an SQL query for the given
+// dialect.
+func (q CustomQuery) ToSQLDialect(dialect Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	return q.ToSQL()
+}
+
 // As returns a new CustomQuery with the new alias i.e. 'field AS Alias'.
 func (q CustomQuery) As(alias string) CustomQuery {
 	q.Alias = alias
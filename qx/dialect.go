@@ -0,0 +1,260 @@
+package qx
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect represents the flavour of SQL spoken by a database. Queries are
+// always built using MySQL style ? placeholders and ANSI style "double quoted"
+// identifiers, and are only converted into the dialect's own placeholders and
+// identifier quotes when the toplevel query is rendered (see Rebind).
+type Dialect interface {
+	// Placeholder returns the placeholder for the i-th (starting from 1) bind
+	// parameter of a query.
+	Placeholder(i int) string
+	// QuoteIdentifier quotes an identifier (a table name, column name etc).
+	QuoteIdentifier(name string) string
+	// BooleanLiteral returns the SQL representation of a boolean value.
+	BooleanLiteral(b bool) string
+	// InterpolateSQL interpolates the args into a query that has already been
+	// rebound to the dialect. It is only meant for logging, not for executing
+	// the query.
+	InterpolateSQL(query string, args ...interface{}) string
+}
+
+// Dialects
+var (
+	Postgres  Dialect = postgresDialect{}
+	MySQL     Dialect = mysqlDialect{}
+	SQLite    Dialect = sqliteDialect{}
+	SQLServer Dialect = sqlserverDialect{}
+	Oracle    Dialect = oracleDialect{}
+)
+
+type postgresDialect struct{}
+
+func (d postgresDialect) Placeholder(i int) string { return "$" + strconv.Itoa(i) }
+
+func (d postgresDialect) QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (d postgresDialect) BooleanLiteral(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (d postgresDialect) InterpolateSQL(query string, args ...interface{}) string {
+	return interpolateSQL(d, query, args)
+}
+
+type mysqlDialect struct{}
+
+func (d mysqlDialect) Placeholder(i int) string { return "?" }
+
+func (d mysqlDialect) QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func (d mysqlDialect) BooleanLiteral(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (d mysqlDialect) InterpolateSQL(query string, args ...interface{}) string {
+	return interpolateSQL(d, query, args)
+}
+
+type sqliteDialect struct{}
+
+func (d sqliteDialect) Placeholder(i int) string { return "?" }
+
+func (d sqliteDialect) QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// BooleanLiteral implements the Dialect interface. SQLite only understands
+// TRUE and FALSE from version 3.23.0 onwards, so 1 and 0 are used instead.
+func (d sqliteDialect) BooleanLiteral(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (d sqliteDialect) InterpolateSQL(query string, args ...interface{}) string {
+	return interpolateSQL(d, query, args)
+}
+
+type sqlserverDialect struct{}
+
+func (d sqlserverDialect) Placeholder(i int) string { return "@p" + strconv.Itoa(i) }
+
+func (d sqlserverDialect) QuoteIdentifier(name string) string {
+	return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+}
+
+func (d sqlserverDialect) BooleanLiteral(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (d sqlserverDialect) InterpolateSQL(query string, args ...interface{}) string {
+	return interpolateSQL(d, query, args)
+}
+
+type oracleDialect struct{}
+
+func (d oracleDialect) Placeholder(i int) string { return ":" + strconv.Itoa(i) }
+
+func (d oracleDialect) QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (d oracleDialect) BooleanLiteral(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (d oracleDialect) InterpolateSQL(query string, args ...interface{}) string {
+	return interpolateSQL(d, query, args)
+}
+
+// Rebind converts a query using ? placeholders and "double quoted" identifiers
+// into the placeholders and identifier quotes of the dialect. To escape a
+// literal question mark ? , use two question marks ?? instead. Dialects that
+// use ? as their placeholder only have the escaped question marks unescaped.
+func Rebind(d Dialect, query string) string {
+	if d.Placeholder(1) != "?" {
+		query = rebindPlaceholders(d, query)
+	} else {
+		query = strings.ReplaceAll(query, "??", "?")
+	}
+	if d.QuoteIdentifier("") != `""` {
+		query = rebindQuotes(d, query)
+	}
+	return query
+}
+
+// rebindPlaceholders replaces all ? in the query with the dialect's
+// placeholders. Two question marks ?? are replaced with a literal question
+// mark ? instead.
+func rebindPlaceholders(d Dialect, query string) string {
+	buf := &strings.Builder{}
+	i := 0
+	for {
+		p := strings.Index(query, "?")
+		if p < 0 {
+			break
+		}
+		buf.WriteString(query[:p])
+		if len(query[p:]) > 1 && query[p:p+2] == "??" {
+			buf.WriteString("?")
+			query = query[p+2:]
+		} else {
+			i++
+			buf.WriteString(d.Placeholder(i))
+			query = query[p+1:]
+		}
+	}
+	buf.WriteString(query)
+	return buf.String()
+}
+
+// rebindQuotes replaces all "double quoted" identifiers in the query with the
+// dialect's quoted identifiers. Single quoted string literals are left
+// untouched.
+func rebindQuotes(d Dialect, query string) string {
+	if !strings.Contains(query, `"`) {
+		return query
+	}
+	buf := &strings.Builder{}
+	for {
+		p := strings.IndexAny(query, `'"`)
+		if p < 0 {
+			break
+		}
+		buf.WriteString(query[:p])
+		quote := query[p]
+		// look for the closing quote, skipping over any doubled (escaped) quotes
+		end := p + 1
+		for end < len(query) {
+			if query[end] == quote {
+				if end+1 < len(query) && query[end+1] == quote {
+					end += 2
+					continue
+				}
+				break
+			}
+			end++
+		}
+		if end >= len(query) {
+			// unterminated quote, write out the rest of the query as-is
+			buf.WriteString(query[p:])
+			query = ""
+			break
+		}
+		if quote == '\'' {
+			buf.WriteString(query[p : end+1])
+		} else {
+			buf.WriteString(d.QuoteIdentifier(strings.ReplaceAll(query[p+1:end], `""`, `"`)))
+		}
+		query = query[end+1:]
+	}
+	buf.WriteString(query)
+	return buf.String()
+}
+
+// interpolateSQL interpolates the args into a query that has already been
+// rebound to the dialect d.
+func interpolateSQL(d Dialect, query string, args []interface{}) string {
+	argToString := func(arg interface{}) string {
+		if b, ok := arg.(bool); ok {
+			return d.BooleanLiteral(b)
+		}
+		return ArgToString(arg)
+	}
+	if d.Placeholder(1) == "?" {
+		buf := &strings.Builder{}
+		// i is the position of the ? in the query
+		for i := strings.Index(query, "?"); i >= 0 && len(args) > 0; i = strings.Index(query, "?") {
+			buf.WriteString(query[:i])
+			if len(query[i:]) > 1 && query[i:i+2] == "??" {
+				buf.WriteString("?")
+				query = query[i+2:]
+				continue
+			}
+			buf.WriteString(argToString(args[0]))
+			query = query[i+1:]
+			args = args[1:]
+		}
+		buf.WriteString(query)
+		return buf.String()
+	}
+	// Replace the longer placeholders first so that $1 does not clobber $10
+	oldnewSets := make(map[int][]string)
+	maxlen := 0
+	for i, arg := range args {
+		placeholder := d.Placeholder(i + 1)
+		oldnewSets[len(placeholder)] = append(oldnewSets[len(placeholder)], placeholder, argToString(arg))
+		if len(placeholder) > maxlen {
+			maxlen = len(placeholder)
+		}
+	}
+	result := query
+	for i := maxlen; i > 0; i-- {
+		if len(oldnewSets[i]) > 0 {
+			result = strings.NewReplacer(oldnewSets[i]...).Replace(result)
+		}
+	}
+	return result
+}
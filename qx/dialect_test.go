@@ -0,0 +1,127 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRebind(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		dialect     Dialect
+		input       string
+		want        string
+	}
+	query := `SELECT "user"."order", ? FROM "user" WHERE "user".name = 'say "hi"' AND "user".age > ? -- escape this ??`
+	tests := []TT{
+		{
+			"Postgres",
+			Postgres,
+			query,
+			`SELECT "user"."order", $1 FROM "user" WHERE "user".name = 'say "hi"' AND "user".age > $2 -- escape this ?`,
+		},
+		{
+			"MySQL",
+			MySQL,
+			query,
+			"SELECT `user`.`order`, ? FROM `user` WHERE `user`.name = 'say \"hi\"' AND `user`.age > ? -- escape this ?",
+		},
+		{
+			"SQLite",
+			SQLite,
+			query,
+			`SELECT "user"."order", ? FROM "user" WHERE "user".name = 'say "hi"' AND "user".age > ? -- escape this ?`,
+		},
+		{
+			"SQLServer",
+			SQLServer,
+			query,
+			`SELECT [user].[order], @p1 FROM [user] WHERE [user].name = 'say "hi"' AND [user].age > @p2 -- escape this ?`,
+		},
+		{
+			"Oracle",
+			Oracle,
+			query,
+			`SELECT "user"."order", :1 FROM "user" WHERE "user".name = 'say "hi"' AND "user".age > :2 -- escape this ?`,
+		},
+		{
+			"MySQL escaped quotes and backticks",
+			MySQL,
+			`SELECT "a""b", "c` + "`" + `d", 'O''Brien'`,
+			"SELECT `a\"b`, `c``d`, 'O''Brien'",
+		},
+		{
+			"SQLServer escaped brackets",
+			SQLServer,
+			`SELECT "a]b"`,
+			"SELECT [a]]b]",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			got := Rebind(tt.dialect, tt.input)
+			is.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestDialect_InterpolateSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		dialect     Dialect
+		query       string
+		args        []interface{}
+		want        string
+	}
+	args := []interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9, true, "ten"}
+	tests := []TT{
+		{
+			"Postgres",
+			Postgres,
+			"SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11",
+			args,
+			"SELECT 1, 2, 3, 4, 5, 6, 7, 8, 9, TRUE, 'ten'",
+		},
+		{
+			"MySQL",
+			MySQL,
+			"SELECT ??, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?",
+			args,
+			"SELECT ?, 1, 2, 3, 4, 5, 6, 7, 8, 9, TRUE, 'ten'",
+		},
+		{
+			"SQLite",
+			SQLite,
+			"SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?",
+			args,
+			"SELECT 1, 2, 3, 4, 5, 6, 7, 8, 9, 1, 'ten'",
+		},
+		{
+			"SQLServer",
+			SQLServer,
+			"SELECT @p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11",
+			args,
+			"SELECT 1, 2, 3, 4, 5, 6, 7, 8, 9, 1, 'ten'",
+		},
+		{
+			"Oracle",
+			Oracle,
+			"SELECT :1, :2, :3, :4, :5, :6, :7, :8, :9, :10, :11",
+			args,
+			"SELECT 1, 2, 3, 4, 5, 6, 7, 8, 9, 1, 'ten'",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			got := tt.dialect.InterpolateSQL(tt.query, tt.args...)
+			is.Equal(tt.want, got)
+		})
+	}
+}
@@ -144,3 +144,64 @@ func (sets FieldValueSets) WriteSQL(buf *strings.Builder, args *[]interface{}, p
 	}
 	return false
 }
+
+// containsExpression reports whether v, or any of the Fields, Predicates and
+// values nested inside it, satisfies match. match is given the format of each
+// expression (if it has one) as well as the expression itself. Subqueries are
+// not descended into, because their expressions do not belong to the
+// enclosing query.
+func containsExpression(v interface{}, match func(format string, v interface{}) bool) bool {
+	var format string
+	var values []interface{}
+	switch v := v.(type) {
+	case NumberField:
+		format, values = formatOf(v.format), v.values
+	case StringField:
+		format, values = formatOf(v.format), v.values
+	case TimeField:
+		format, values = formatOf(v.format), v.values
+	case BooleanField:
+		format, values = formatOf(v.format), v.values
+	case JSONField:
+		format, values = formatOf(v.format), v.values
+	case ArrayField:
+		format, values = formatOf(v.format), v.values
+	case CustomField:
+		format, values = v.Format, v.Values
+	case CustomPredicate:
+		format, values = v.Format, v.Values
+	case UnaryPredicate:
+		values = []interface{}{v.Field}
+	case BinaryPredicate:
+		values = []interface{}{v.LeftField, v.RightField}
+	case TernaryPredicate:
+		values = []interface{}{v.Field, v.FieldX, v.FieldY}
+	case VariadicPredicate:
+		for i := range v.Predicates {
+			values = append(values, v.Predicates[i])
+		}
+	case CaseExpr:
+		values = []interface{}{v.Operand, v.Default}
+		for i := range v.Whens {
+			values = append(values, v.Whens[i].When, v.Whens[i].Then)
+		}
+	case aggregate:
+		values = v.args
+	}
+	if match(format, v) {
+		return true
+	}
+	for i := range values {
+		if values[i] != nil && containsExpression(values[i], match) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatOf(format *string) string {
+	if format == nil {
+		return ""
+	}
+	return *format
+}
@@ -0,0 +1,164 @@
+package qx
+
+import "strings"
+
+// functionFormat returns the format string 'name(?, ?, ...)' for a function
+// call with n arguments.
+func functionFormat(name string, n int) string {
+	if n == 0 {
+		return name + "()"
+	}
+	return name + "(?" + strings.Repeat(", ?", n-1) + ")"
+}
+
+// Coalesce returns a new CustomField representing the 'COALESCE(field,
+// values...)' function, which evaluates to the first value that is not NULL.
+// The values can be Fields or Go values. For a typed result use
+// CoalesceNumber, CoalesceString etc.
+func Coalesce(field Field, values ...interface{}) CustomField {
+	return CustomField{
+		Format: functionFormat("COALESCE", len(values)+1),
+		Values: append([]interface{}{field}, values...),
+	}
+}
+
+// CoalesceNumber is like Coalesce but it returns a NumberField.
+func CoalesceNumber(field NumberField, values ...interface{}) NumberField {
+	return NumberFieldf(functionFormat("COALESCE", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// CoalesceString is like Coalesce but it returns a StringField.
+func CoalesceString(field StringField, values ...interface{}) StringField {
+	return StringFieldf(functionFormat("COALESCE", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// CoalesceTime is like Coalesce but it returns a TimeField.
+func CoalesceTime(field TimeField, values ...interface{}) TimeField {
+	return TimeFieldf(functionFormat("COALESCE", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// CoalesceBoolean is like Coalesce but it returns a BooleanField.
+func CoalesceBoolean(field BooleanField, values ...interface{}) BooleanField {
+	return BooleanFieldf(functionFormat("COALESCE", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// NullIf returns a new CustomField representing the 'NULLIF(field, value)'
+// function, which evaluates to NULL if field equals value and field
+// otherwise. For a typed result use NullIfNumber, NullIfString etc.
+func NullIf(field Field, value interface{}) CustomField {
+	return CustomField{
+		Format: "NULLIF(?, ?)",
+		Values: []interface{}{field, value},
+	}
+}
+
+// NullIfNumber is like NullIf but it returns a NumberField.
+func NullIfNumber(field NumberField, value interface{}) NumberField {
+	return NumberFieldf("NULLIF(?, ?)", field, value)
+}
+
+// NullIfString is like NullIf but it returns a StringField.
+func NullIfString(field StringField, value interface{}) StringField {
+	return StringFieldf("NULLIF(?, ?)", field, value)
+}
+
+// NullIfTime is like NullIf but it returns a TimeField.
+func NullIfTime(field TimeField, value interface{}) TimeField {
+	return TimeFieldf("NULLIF(?, ?)", field, value)
+}
+
+// NullIfBoolean is like NullIf but it returns a BooleanField.
+func NullIfBoolean(field BooleanField, value interface{}) BooleanField {
+	return BooleanFieldf("NULLIF(?, ?)", field, value)
+}
+
+// Greatest returns a new CustomField representing the 'GREATEST(field, values...)'
+// function, which evaluates to the largest value (postgres and mysql only). For
+// a typed result use GreatestNumber, GreatestString etc.
+func Greatest(field Field, values ...interface{}) CustomField {
+	return CustomField{
+		Format: functionFormat("GREATEST", len(values)+1),
+		Values: append([]interface{}{field}, values...),
+	}
+}
+
+// GreatestNumber is like Greatest but it returns a NumberField.
+func GreatestNumber(field NumberField, values ...interface{}) NumberField {
+	return NumberFieldf(functionFormat("GREATEST", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// GreatestString is like Greatest but it returns a StringField.
+func GreatestString(field StringField, values ...interface{}) StringField {
+	return StringFieldf(functionFormat("GREATEST", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// GreatestTime is like Greatest but it returns a TimeField.
+func GreatestTime(field TimeField, values ...interface{}) TimeField {
+	return TimeFieldf(functionFormat("GREATEST", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// GreatestBoolean is like Greatest but it returns a BooleanField.
+func GreatestBoolean(field BooleanField, values ...interface{}) BooleanField {
+	return BooleanFieldf(functionFormat("GREATEST", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// Least returns a new CustomField representing the 'LEAST(field, values...)'
+// function, which evaluates to the smallest value (postgres and mysql only). For
+// a typed result use LeastNumber, LeastString etc.
+func Least(field Field, values ...interface{}) CustomField {
+	return CustomField{
+		Format: functionFormat("LEAST", len(values)+1),
+		Values: append([]interface{}{field}, values...),
+	}
+}
+
+// LeastNumber is like Least but it returns a NumberField.
+func LeastNumber(field NumberField, values ...interface{}) NumberField {
+	return NumberFieldf(functionFormat("LEAST", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// LeastString is like Least but it returns a StringField.
+func LeastString(field StringField, values ...interface{}) StringField {
+	return StringFieldf(functionFormat("LEAST", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// LeastTime is like Least but it returns a TimeField.
+func LeastTime(field TimeField, values ...interface{}) TimeField {
+	return TimeFieldf(functionFormat("LEAST", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// LeastBoolean is like Least but it returns a BooleanField.
+func LeastBoolean(field BooleanField, values ...interface{}) BooleanField {
+	return BooleanFieldf(functionFormat("LEAST", len(values)+1), append([]interface{}{field}, values...)...)
+}
+
+// Cast returns a new CustomField representing the 'CAST(field AS typ)'
+// expression. As typ is a keyword it cannot be passed as an argument, so it
+// must never come from user input. For a typed result use CastNumber,
+// CastString etc.
+func Cast(field Field, typ string) CustomField {
+	return CustomField{
+		Format: "CAST(? AS " + typ + ")",
+		Values: []interface{}{field},
+	}
+}
+
+// CastNumber is like Cast but it returns a NumberField.
+func CastNumber(field Field, typ string) NumberField {
+	return NumberFieldf("CAST(? AS "+typ+")", field)
+}
+
+// CastString is like Cast but it returns a StringField.
+func CastString(field Field, typ string) StringField {
+	return StringFieldf("CAST(? AS "+typ+")", field)
+}
+
+// CastTime is like Cast but it returns a TimeField.
+func CastTime(field Field, typ string) TimeField {
+	return TimeFieldf("CAST(? AS "+typ+")", field)
+}
+
+// CastBoolean is like Cast but it returns a BooleanField.
+func CastBoolean(field Field, typ string) BooleanField {
+	return BooleanFieldf("CAST(? AS "+typ+")", field)
+}
@@ -0,0 +1,52 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestFunctions_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	cust, film, pay := CUSTOMER(), FILM(), PAYMENT()
+	tests := []TT{
+		{"Coalesce", Coalesce(film.ORIGINAL_LANGUAGE_ID, film.LANGUAGE_ID), "COALESCE(film.original_language_id, film.language_id)", nil},
+		{"CoalesceNumber", CoalesceNumber(pay.AMOUNT, 0).As("amount"), "COALESCE(payment.amount, ?)", []interface{}{0}},
+		{"CoalesceString", CoalesceString(film.DESCRIPTION, film.TITLE, "n/a"), "COALESCE(film.description, film.title, ?)", []interface{}{"n/a"}},
+		{"CoalesceTime", CoalesceTime(pay.PAYMENT_DATE, Now()), "COALESCE(payment.payment_date, CURRENT_TIMESTAMP)", nil},
+		{"CoalesceBoolean", CoalesceBoolean(cust.ACTIVEBOOL, false), "COALESCE(customer.activebool, ?)", []interface{}{false}},
+		{"NullIf", NullIf(film.TITLE, ""), "NULLIF(film.title, ?)", []interface{}{""}},
+		{"NullIfNumber", NullIfNumber(pay.AMOUNT, 0), "NULLIF(payment.amount, ?)", []interface{}{0}},
+		{"Greatest", Greatest(pay.AMOUNT, 1), "GREATEST(payment.amount, ?)", []interface{}{1}},
+		{"GreatestTime", GreatestTime(pay.PAYMENT_DATE, Now()), "GREATEST(payment.payment_date, CURRENT_TIMESTAMP)", nil},
+		{"LeastNumber", LeastNumber(pay.AMOUNT, 10, pay.STAFF_ID), "LEAST(payment.amount, ?, payment.staff_id)", []interface{}{10}},
+		{"Cast", Cast(pay.AMOUNT, "TEXT"), "CAST(payment.amount AS TEXT)", nil},
+		{"CastNumber", CastNumber(film.TITLE.Length(), "NUMERIC").Desc(), "CAST(char_length(film.title) AS NUMERIC) DESC", nil},
+		{"CastString", CastString(pay.AMOUNT, "TEXT"), "CAST(payment.amount AS TEXT)", nil},
+		{"CastTime", CastTime(film.TITLE, "DATE"), "CAST(film.title AS DATE)", nil},
+		{"CastBoolean", CastBoolean(pay.STAFF_ID, "BOOLEAN"), "CAST(payment.staff_id AS BOOLEAN)", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.f.ToSQLExclude(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestFunctions_TypedPredicates(t *testing.T) {
+	is := is.New(t)
+	pay := PAYMENT()
+	query, args := CoalesceNumber(pay.AMOUNT, 0).GtInt(5).ToSQLExclude(nil)
+	is.Equal("COALESCE(payment.amount, ?) > ?", query)
+	is.Equal([]interface{}{0, 5}, args)
+}
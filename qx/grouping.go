@@ -0,0 +1,54 @@
+package qx
+
+import (
+	"strings"
+)
+
+// Rollup returns a new CustomField representing the 'ROLLUP (fields)'
+// grouping element, to be passed to GroupBy (postgres only). It groups by the
+// fields as well as every prefix of the fields, down to the grand total.
+func Rollup(fields ...Field) CustomField {
+	return CustomField{
+		Format: "ROLLUP (?)",
+		Values: []interface{}{Fields(fields)},
+	}
+}
+
+// Cube returns a new CustomField representing the 'CUBE (fields)' grouping
+// element, to be passed to GroupBy (postgres only). It groups by every
+// possible subset of the fields.
+func Cube(fields ...Field) CustomField {
+	return CustomField{
+		Format: "CUBE (?)",
+		Values: []interface{}{Fields(fields)},
+	}
+}
+
+// GroupingSets returns a new CustomField representing the 'GROUPING SETS
+// ((set1), (set2), ...)' grouping element, to be passed to GroupBy (postgres
+// only). An empty set represents the grand total i.e. '()'.
+func GroupingSets(sets ...Fields) CustomField {
+	format := &strings.Builder{}
+	values := make([]interface{}, len(sets))
+	format.WriteString("GROUPING SETS (")
+	for i := range sets {
+		if i > 0 {
+			format.WriteString(", ")
+		}
+		format.WriteString("(?)")
+		values[i] = sets[i]
+	}
+	format.WriteString(")")
+	return CustomField{
+		Format: format.String(),
+		Values: values,
+	}
+}
+
+// Grouping returns a new NumberField representing the 'GROUPING(fields)'
+// function, which is a bit mask of the fields that are not part of the
+// current grouping set. It is used to tell subtotal rows (where it is nonzero)
+// apart from detail rows when using Rollup, Cube or GroupingSets.
+func Grouping(fields ...Field) NumberField {
+	return NumberFieldf("GROUPING(?)", Fields(fields))
+}
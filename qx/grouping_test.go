@@ -0,0 +1,38 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGrouping_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	pay := PAYMENT()
+	tests := []TT{
+		{"ROLLUP", Rollup(pay.STAFF_ID, pay.CUSTOMER_ID), "ROLLUP (payment.staff_id, payment.customer_id)", nil},
+		{"CUBE", Cube(pay.STAFF_ID, pay.PAYMENT_DATE.DateTrunc("month")), "CUBE (payment.staff_id, date_trunc('month', payment.payment_date))", nil},
+		{
+			"GROUPING SETS",
+			GroupingSets(Fields{pay.STAFF_ID, pay.CUSTOMER_ID}, Fields{pay.STAFF_ID}, Fields{}),
+			"GROUPING SETS ((payment.staff_id, payment.customer_id), (payment.staff_id), ())",
+			nil,
+		},
+		{"GROUPING", Grouping(pay.STAFF_ID, pay.CUSTOMER_ID).As("grp"), "GROUPING(payment.staff_id, payment.customer_id)", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.f.ToSQLExclude(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package qx
+
+import (
+	"strings"
+)
+
+// reservedWords is the set of keywords that cannot be used as bare identifiers
+// in at least one of the supported dialects.
+var reservedWords = map[string]struct{}{
+	"all": {}, "alter": {}, "analyse": {}, "analyze": {}, "and": {}, "any": {},
+	"array": {}, "as": {}, "asc": {}, "asymmetric": {}, "between": {},
+	"both": {}, "by": {}, "case": {}, "cast": {}, "check": {}, "collate": {},
+	"column": {}, "constraint": {}, "create": {}, "cross": {},
+	"current_date": {}, "current_role": {}, "current_time": {},
+	"current_timestamp": {}, "current_user": {}, "default": {},
+	"deferrable": {}, "delete": {}, "desc": {}, "distinct": {}, "do": {},
+	"drop": {}, "else": {}, "end": {}, "except": {}, "exists": {},
+	"false": {}, "fetch": {}, "for": {}, "foreign": {}, "from": {},
+	"full": {}, "grant": {}, "group": {}, "having": {}, "in": {},
+	"index": {}, "initially": {}, "inner": {}, "insert": {},
+	"intersect": {}, "into": {}, "is": {}, "join": {}, "key": {},
+	"lateral": {}, "leading": {}, "left": {}, "like": {}, "limit": {},
+	"localtime": {}, "localtimestamp": {}, "natural": {}, "not": {},
+	"null": {}, "offset": {}, "on": {}, "only": {}, "or": {}, "order": {},
+	"outer": {}, "placing": {}, "primary": {}, "range": {},
+	"references": {}, "returning": {}, "right": {}, "rows": {},
+	"select": {}, "session_user": {}, "set": {}, "some": {},
+	"symmetric": {}, "table": {}, "then": {}, "to": {}, "trailing": {},
+	"true": {}, "union": {}, "unique": {}, "update": {}, "user": {},
+	"using": {}, "values": {}, "when": {}, "where": {}, "window": {},
+	"with": {},
+}
+
+// QuoteIdentifier "double quotes" an identifier if it is a reserved keyword,
+// contains uppercase letters or contains characters other than lowercase
+// letters, digits and underscores. Otherwise the identifier is returned as-is.
+// The "double quotes" are converted into the dialect's own identifier quotes
+// when the toplevel query is rendered (see Rebind).
+func QuoteIdentifier(name string) string {
+	if !needsQuoting(name) {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// needsQuoting reports whether an identifier must be quoted in order to be
+// used in a query.
+func needsQuoting(name string) bool {
+	if name == "" {
+		return false
+	}
+	if _, ok := reservedWords[name]; ok {
+		return true
+	}
+	for i, char := range name {
+		switch {
+		case char >= 'a' && char <= 'z', char == '_':
+			continue
+		case char >= '0' && char <= '9' && i > 0:
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		name        string
+		want        string
+	}
+	tests := []TT{
+		{"plain identifier", "first_name", "first_name"},
+		{"identifier with digits", "payment_p2007_01", "payment_p2007_01"},
+		{"empty identifier", "", ""},
+		{"reserved keyword", "order", `"order"`},
+		{"reserved keyword user", "user", `"user"`},
+		{"mixed case", "firstName", `"firstName"`},
+		{"leading digit", "1st", `"1st"`},
+		{"special characters", "first name", `"first name"`},
+		{"embedded double quote", `a"b`, `"a""b"`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			is.Equal(tt.want, QuoteIdentifier(tt.name))
+		})
+	}
+}
+
+func TestQuoteIdentifier_Fields(t *testing.T) {
+	type TT struct {
+		DESCRIPTION            string
+		f                      Field
+		excludeTableQualifiers []string
+		wantQuery              string
+	}
+	user := NewTableInfo("public", "user")
+	userAccounts := NewTableInfo("Accounts", "userAccounts")
+	tests := []TT{
+		{"reserved table and column", NewNumberField("order", user), nil, `"user"."order"`},
+		{"mixed case table and column", NewStringField("displayName", userAccounts), nil, `"userAccounts"."displayName"`},
+		{"excluded table qualifier", NewTimeField("createdAt", userAccounts), []string{"userAccounts"}, `"createdAt"`},
+		{"aliases are not quoted", NewBooleanField("is_active", &TableInfo{Name: "user", Alias: "u"}), nil, `u.is_active`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, _ := tt.f.ToSQLExclude(tt.excludeTableQualifiers)
+			is.Equal(tt.wantQuery, gotQuery)
+		})
+	}
+	t.Run("TableInfo", func(t *testing.T) {
+		t.Parallel()
+		is := is.New(t)
+		gotQuery, _ := user.ToSQL()
+		is.Equal(`"user"`, gotQuery)
+		gotQuery, _ = userAccounts.ToSQL()
+		is.Equal(`"Accounts"."userAccounts"`, gotQuery)
+	})
+}
@@ -4,15 +4,20 @@ import (
 	"strings"
 )
 
-// JoinTable represents an SQL join.
+// JoinTable represents an SQL join. If Lateral is true the Table is joined
+// as a LATERAL subquery, which may refer to the tables before it. If
+// UsingFields is not empty the join condition is 'USING (fields)' instead of
+// 'ON predicates'.
 type JoinTable struct {
 	JoinType     JoinType
+	Lateral      bool
 	Table        Table
 	OnPredicates VariadicPredicate
+	UsingFields  Fields
 }
 
 // Join constructs a new JoinTable. Meant to be used if you want to do a custom
-// join, like NATURAL JOIN.
+// join, like NATURAL LEFT JOIN.
 func Join(joinType string, table Table, predicates ...Predicate) JoinTable {
 	return JoinTable{
 		JoinType: JoinType(joinType),
@@ -56,15 +61,34 @@ func (joins JoinTables) WriteSQL(buf *strings.Builder, args *[]interface{}) (wri
 		if buf.Len() > 0 {
 			buf.WriteString(" ")
 		}
+		buf.WriteString(string(joins[i].JoinType) + " ")
+		if joins[i].Lateral {
+			buf.WriteString("LATERAL ")
+		}
 		if joins[i].Table.GetAlias() != "" {
-			buf.WriteString(string(joins[i].JoinType) + " " + tableQuery + " AS " + joins[i].Table.GetAlias())
+			buf.WriteString(tableQuery + " AS " + TableAlias(joins[i].Table))
 		} else {
-			buf.WriteString(string(joins[i].JoinType) + " " + tableQuery)
+			buf.WriteString(tableQuery)
 		}
 		*args = append(*args, tableArgs...)
 		written = true
+		if len(joins[i].UsingFields) > 0 {
+			names := make([]string, 0, len(joins[i].UsingFields))
+			for _, field := range joins[i].UsingFields {
+				if field == nil {
+					continue
+				}
+				names = append(names, QuoteIdentifier(field.GetName()))
+			}
+			buf.WriteString(" USING (" + strings.Join(names, ", ") + ")")
+			continue
+		}
 		joins[i].OnPredicates.Toplevel = true
-		joins[i].OnPredicates.WriteSQL(buf, args, "ON ", "", nil)
+		if !joins[i].OnPredicates.WriteSQL(buf, args, "ON ", "", nil) && joins[i].Lateral && joins[i].JoinType != JoinTypeCross {
+			// A LATERAL join still needs a join condition, even if the
+			// subquery is already correlated through its WHERE clause.
+			buf.WriteString(" ON TRUE")
+		}
 	}
 	return written
 }
@@ -1 +1,68 @@
 package qx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestJoinTables_WriteSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		joins       JoinTables
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	cust, addr := CUSTOMER(), ADDRESS().As("a")
+	tests := []TT{
+		{
+			"JOIN ON",
+			JoinTables{Join(string(JoinTypeLeft), addr, addr.ADDRESS_ID.Eq(cust.ADDRESS_ID))},
+			"LEFT JOIN address AS a ON a.address_id = customer.address_id",
+			nil,
+		},
+		{
+			"JOIN USING renders the fields unqualified",
+			JoinTables{{JoinType: JoinTypeDefault, Table: addr, UsingFields: Fields{cust.ADDRESS_ID, addr.LAST_UPDATE}}},
+			"JOIN address AS a USING (address_id, last_update)",
+			nil,
+		},
+		{
+			"NATURAL JOIN",
+			JoinTables{{JoinType: JoinTypeNatural, Table: addr}},
+			"NATURAL JOIN address AS a",
+			nil,
+		},
+		{
+			"LATERAL without predicates",
+			JoinTables{{JoinType: JoinTypeLeft, Lateral: true, Table: addr}},
+			"LEFT JOIN LATERAL address AS a ON TRUE",
+			nil,
+		},
+		{
+			"LATERAL with predicates",
+			JoinTables{{JoinType: JoinTypeDefault, Lateral: true, Table: addr, OnPredicates: VariadicPredicate{Predicates: []Predicate{addr.ADDRESS_ID.GtInt(5)}}}},
+			"JOIN LATERAL address AS a ON a.address_id > ?",
+			[]interface{}{5},
+		},
+		{
+			"CROSS JOIN LATERAL",
+			JoinTables{{JoinType: JoinTypeCross, Lateral: true, Table: addr}},
+			"CROSS JOIN LATERAL address AS a",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			buf := &strings.Builder{}
+			var gotArgs []interface{}
+			tt.joins.WriteSQL(buf, &gotArgs)
+			is.Equal(tt.wantQuery, buf.String())
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
@@ -3,17 +3,29 @@ package qx
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"strconv"
+	"strings"
 )
 
-// JSONField either represents a JSON column or a literal value that can be
-// marshalled into a JSON string.
+// JSONField either represents a JSON column, a JSON expression or a literal
+// value that can be marshalled into a JSON string.
 type JSONField struct {
 	// JSONField will be one of the following:
 
-	// 1) Literal JSONable value (almost all structs can be converted to JSON)
+	// 1) JSON expression
+	// Examples of JSON expressions:
+	// | query                     | args |
+	// |---------------------------|------|
+	// | users.data -> ?           | name |
+	// | users.data #> ARRAY[?, ?] | a, b |
+	// | users.data - ?            | name |
+	format *string
+	values []interface{}
+
+	// 2) Literal JSONable value (almost all structs can be converted to JSON)
 	value interface{}
 
-	// 2) JSON column
+	// 3) JSON column
 	alias      string
 	table      Table
 	name       string
@@ -34,7 +46,18 @@ func (j jsonwrapper) MarshalJSON() ([]byte, error) {
 // in the excludeTableQualifiers list, the output column name will not be table
 // qualified.
 func (f JSONField) ToSQLExclude(excludeTableQualifiers []string) (string, []interface{}) {
-	// 1) Literal JSONable value
+	// 1) JSON expression
+	if f.format != nil {
+		return CustomField{
+			Alias:        f.alias,
+			Format:       *f.format,
+			Values:       f.values,
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
+		}.ToSQLExclude(excludeTableQualifiers)
+	}
+
+	// 2) Literal JSONable value
 	if f.value != nil {
 		switch f.value.(type) {
 		case json.Marshaler:
@@ -44,22 +67,28 @@ func (f JSONField) ToSQLExclude(excludeTableQualifiers []string) (string, []inte
 		}
 	}
 
-	// 2) JSON column
+	// 3) JSON column
 	var tableQualifier string
 	if f.table != nil {
 		if f.table.GetAlias() != "" {
-			tableQualifier = f.table.GetAlias() + "."
+			tableQualifier = f.table.GetAlias()
 		} else if f.table.GetName() != "" {
-			tableQualifier = f.table.GetName() + "."
+			tableQualifier = f.table.GetName()
 		}
 	}
 	for i := range excludeTableQualifiers {
-		if tableQualifier == excludeTableQualifiers[i]+"." {
+		if tableQualifier == excludeTableQualifiers[i] {
 			tableQualifier = ""
 			break
 		}
 	}
-	columnName := tableQualifier + f.name
+	columnName := QuoteIdentifier(f.name)
+	if tableQualifier != "" {
+		if f.table.GetAlias() == "" {
+			tableQualifier = QuoteIdentifier(tableQualifier)
+		}
+		columnName = tableQualifier + "." + columnName
+	}
 	if f.descending != nil {
 		if *f.descending {
 			columnName = columnName + " DESC"
@@ -85,6 +114,18 @@ func NewJSONField(name string, table Table) JSONField {
 	}
 }
 
+// JSONFieldf follows a printf-like syntax that takes in multiple values and
+// returns a JSONField formatted according to an arbitrary format string. The
+// only recognized format specifier is the ? question mark. E.g.
+// JSONFieldf("jsonb_strip_nulls(?)", field). Values are interpolated as
+// described in FormatPreprocessor.
+func JSONFieldf(format string, values ...interface{}) JSONField {
+	return JSONField{
+		format: &format,
+		values: values,
+	}
+}
+
 // JSON returns a new JSONField representing a literal JSONable value. It
 // returns an error indicating if the value can be marshalled into JSON.
 func JSON(val interface{}) (JSONField, error) {
@@ -196,6 +237,122 @@ func (f JSONField) IsNotNull() Predicate {
 	}
 }
 
+// Get returns a new JSONField representing the JSON object field identified
+// by key i.e. 'A -> key' (postgres only).
+func (f JSONField) Get(key string) JSONField {
+	return JSONFieldf("(? -> ?)", f, key)
+}
+
+// GetText returns a new StringField representing the JSON object field
+// identified by key as text i.e. 'A ->> key' (postgres only).
+func (f JSONField) GetText(key string) StringField {
+	return StringFieldf("(? ->> ?)", f, key)
+}
+
+// Index returns a new JSONField representing the n-th (starting from 0)
+// element of a JSON array i.e. 'A -> n' (postgres only).
+func (f JSONField) Index(n int) JSONField {
+	return JSONFieldf("(? -> "+strconv.Itoa(n)+")", f)
+}
+
+// Path returns a new JSONField representing the JSON object at the specified
+// path i.e. 'A #> ARRAY[keys]' (postgres only).
+func (f JSONField) Path(keys ...string) JSONField {
+	format, values := textArray(keys)
+	return JSONFieldf("(? #> "+format+")", append([]interface{}{f}, values...)...)
+}
+
+// PathText returns a new StringField representing the JSON object at the
+// specified path as text i.e. 'A #>> ARRAY[keys]' (postgres only).
+func (f JSONField) PathText(keys ...string) StringField {
+	format, values := textArray(keys)
+	return StringFieldf("(? #>> "+format+")", append([]interface{}{f}, values...)...)
+}
+
+// Contains returns an 'A @> B' Predicate (postgres only). If B is not a Field,
+// it is treated as a JSONable value (see MustJSON).
+func (f JSONField) Contains(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? @> ?",
+		Values: []interface{}{f, jsonValue(v)},
+	}
+}
+
+// ContainedBy returns an 'A <@ B' Predicate (postgres only). If B is not a
+// Field, it is treated as a JSONable value (see MustJSON).
+func (f JSONField) ContainedBy(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <@ ?",
+		Values: []interface{}{f, jsonValue(v)},
+	}
+}
+
+// HasKey returns an 'A ? key' Predicate (postgres only).
+func (f JSONField) HasKey(key string) Predicate {
+	return CustomPredicate{
+		Format: "? ?? ?",
+		Values: []interface{}{f, key},
+	}
+}
+
+// HasAnyKeys returns an 'A ?| ARRAY[keys]' Predicate (postgres only).
+func (f JSONField) HasAnyKeys(keys ...string) Predicate {
+	format, values := textArray(keys)
+	return CustomPredicate{
+		Format: "? ??| " + format,
+		Values: append([]interface{}{f}, values...),
+	}
+}
+
+// HasAllKeys returns an 'A ?& ARRAY[keys]' Predicate (postgres only).
+func (f JSONField) HasAllKeys(keys ...string) Predicate {
+	format, values := textArray(keys)
+	return CustomPredicate{
+		Format: "? ??& " + format,
+		Values: append([]interface{}{f}, values...),
+	}
+}
+
+// JSONBSet returns a new JSONField representing the JSON object with the
+// value at the specified path replaced i.e. 'jsonb_set(A, ARRAY[path], value)'
+// (postgres only). If value is not a Field, it is treated as a JSONable value
+// (see MustJSON). It is meant to be used in an UPDATE query i.e.
+// 'SET field = jsonb_set(field, ...)'.
+func (f JSONField) JSONBSet(path []string, value interface{}) JSONField {
+	format, values := textArray(path)
+	values = append([]interface{}{f}, values...)
+	return JSONFieldf("jsonb_set(?, "+format+", ?)", append(values, jsonValue(value))...)
+}
+
+// DeleteKey returns a new JSONField representing the JSON object with the key
+// removed i.e. 'A - key' (postgres only). It is meant to be used in an UPDATE
+// query i.e. 'SET field = field - key'.
+func (f JSONField) DeleteKey(key string) JSONField {
+	return JSONFieldf("(? - ?)", f, key)
+}
+
+// textArray returns the format and values for an 'ARRAY[?, ?, ?]' of the
+// strings.
+func textArray(strs []string) (string, []interface{}) {
+	if len(strs) == 0 {
+		return "ARRAY[]::TEXT[]", nil
+	}
+	values := make([]interface{}, len(strs))
+	for i := range strs {
+		values[i] = strs[i]
+	}
+	return "ARRAY[?" + strings.Repeat(", ?", len(strs)-1) + "]", values
+}
+
+// jsonValue returns v if it is a Field, otherwise it returns v as a literal
+// JSONField.
+func jsonValue(v interface{}) interface{} {
+	if _, ok := v.(Field); ok {
+		return v
+	}
+	return MustJSON(v)
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a JSONField.
 func (f JSONField) String() string {
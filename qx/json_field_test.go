@@ -0,0 +1,68 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestJSONField_Operators(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           interface {
+			ToSQLExclude([]string) (string, []interface{})
+		}
+		wantQuery string
+		wantArgs  []interface{}
+	}
+	tbl := NewTableInfo("public", "users")
+	data := NewJSONField("data", tbl)
+	tests := []TT{
+		{"Get", data.Get("address").Get("city"), "((users.data -> ?) -> ?)", []interface{}{"address", "city"}},
+		{"GetText", data.GetText("name"), "(users.data ->> ?)", []interface{}{"name"}},
+		{"Index", data.Get("tags").Index(2), "((users.data -> ?) -> 2)", []interface{}{"tags"}},
+		{"Path", data.Path("address", "city"), "(users.data #> ARRAY[?, ?])", []interface{}{"address", "city"}},
+		{"PathText", data.PathText("address", "city"), "(users.data #>> ARRAY[?, ?])", []interface{}{"address", "city"}},
+		{"Contains", data.Contains(data.Get("a")), "users.data @> (users.data -> ?)", []interface{}{"a"}},
+		{"ContainedBy", data.ContainedBy(data.Get("a")), "users.data <@ (users.data -> ?)", []interface{}{"a"}},
+		{"HasKey", data.HasKey("name"), "users.data ?? ?", []interface{}{"name"}},
+		{"HasAnyKeys", data.HasAnyKeys("a", "b"), "users.data ??| ARRAY[?, ?]", []interface{}{"a", "b"}},
+		{"HasAllKeys", data.HasAllKeys("a", "b"), "users.data ??& ARRAY[?, ?]", []interface{}{"a", "b"}},
+		{"JSONBSet", data.JSONBSet([]string{"a", "b"}, data.Get("c")), "jsonb_set(users.data, ARRAY[?, ?], (users.data -> ?))", []interface{}{"a", "b", "c"}},
+		{"JSONBSet empty path", data.JSONBSet(nil, data), "jsonb_set(users.data, ARRAY[]::TEXT[], users.data)", nil},
+		{"DeleteKey", data.DeleteKey("a"), "(users.data - ?)", []interface{}{"a"}},
+		{"GetText comparison", data.GetText("name").EqString("bob"), "(users.data ->> ?) = ?", []interface{}{"name", "bob"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.f.ToSQLExclude(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestJSONField_Placeholders(t *testing.T) {
+	is := is.New(t)
+	data := NewJSONField("data", NewTableInfo("public", "users"))
+	query, args := CustomQuery{
+		Format:  "SELECT ? FROM users WHERE ? AND ?",
+		Values:  []interface{}{data.GetText("name"), data.HasKey("email"), data.HasAnyKeys("a", "b")},
+		Dialect: Postgres,
+	}.ToSQL()
+	is.Equal("SELECT (users.data ->> $1) FROM users WHERE users.data ? $2 AND users.data ?| ARRAY[$3, $4]", query)
+	is.Equal([]interface{}{"name", "email", "a", "b"}, args)
+	is.Equal("SELECT users.data ->> $1 FROM users WHERE users.data ? $2 AND users.data ?| ARRAY[$3, $4]", MySQLToPostgresPlaceholders(
+		"SELECT users.data ->> ? FROM users WHERE users.data ?? ? AND users.data ??| ARRAY[?, ?]",
+	))
+	query, args = CustomQuery{
+		Format:  "UPDATE users SET ?",
+		Values:  []interface{}{FieldValueSets{data.Set(data.JSONBSet([]string{"name"}, "bob")), data.Set(data.DeleteKey("email"))}},
+		Dialect: Postgres,
+	}.ToSQL()
+	is.Equal("UPDATE users SET users.data = jsonb_set(users.data, ARRAY[$1], $2), users.data = (users.data - $3)", query)
+	is.Equal(3, len(args))
+}
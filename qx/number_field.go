@@ -1,5 +1,7 @@
 package qx
 
+import "strconv"
+
 // NumberField either represents a number column, a number expression or a
 // literal number value.
 type NumberField struct {
@@ -13,7 +15,7 @@ type NumberField struct {
 	// | FLOOR(? + tbl.column)  | 5           |
 	// | (ABS(?) + (? % ?)) - ? | -3, 5, 4, 8 |
 	format *string
-	fields []Field
+	values []interface{}
 
 	// 2) Literal number value
 	// Examples of literal number values:
@@ -44,14 +46,12 @@ type NumberField struct {
 func (f NumberField) ToSQLExclude(excludeTableQualifiers []string) (string, []interface{}) {
 	// 1) Number expression
 	if f.format != nil {
-		values := make([]interface{}, len(f.fields))
-		for i := range f.fields {
-			values[i] = f.fields[i]
-		}
 		return CustomField{
-			Alias:  f.alias,
-			Format: *f.format,
-			Values: values,
+			Alias:        f.alias,
+			Format:       *f.format,
+			Values:       f.values,
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
 		}.ToSQLExclude(excludeTableQualifiers)
 	}
 
@@ -64,18 +64,24 @@ func (f NumberField) ToSQLExclude(excludeTableQualifiers []string) (string, []in
 	var tableQualifier string
 	if f.table != nil {
 		if f.table.GetAlias() != "" {
-			tableQualifier = f.table.GetAlias() + "."
+			tableQualifier = f.table.GetAlias()
 		} else if f.table.GetName() != "" {
-			tableQualifier = f.table.GetName() + "."
+			tableQualifier = f.table.GetName()
 		}
 	}
 	for i := range excludeTableQualifiers {
-		if tableQualifier == excludeTableQualifiers[i]+"." {
+		if tableQualifier == excludeTableQualifiers[i] {
 			tableQualifier = ""
 			break
 		}
 	}
-	columnName := tableQualifier + f.name
+	columnName := QuoteIdentifier(f.name)
+	if tableQualifier != "" {
+		if f.table.GetAlias() == "" {
+			tableQualifier = QuoteIdentifier(tableQualifier)
+		}
+		columnName = tableQualifier + "." + columnName
+	}
 	if f.descending != nil {
 		if *f.descending {
 			columnName = columnName + " DESC"
@@ -382,6 +388,130 @@ func (f NumberField) In(v interface{}) Predicate {
 	}
 }
 
+// NotIn returns an 'A NOT IN (B)' Predicate, where B can be anything.
+func (f NumberField) NotIn(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? NOT IN (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// InQuery returns an 'A IN (subquery)' Predicate.
+func (f NumberField) InQuery(subquery Query) Predicate {
+	return CustomPredicate{
+		Format: "? IN (?)",
+		Values: []interface{}{f, subquery},
+	}
+}
+
+// EqAny returns an 'A = ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) EqAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? = ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// NeAny returns an 'A <> ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) NeAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <> ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GtAny returns an 'A > ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) GtAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? > ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GeAny returns an 'A >= ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) GeAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? >= ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LtAny returns an 'A < ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) LtAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? < ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LeAny returns an 'A <= ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) LeAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <= ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// EqAll returns an 'A = ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) EqAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? = ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// NeAll returns an 'A <> ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) NeAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <> ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GtAll returns an 'A > ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) GtAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? > ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GeAll returns an 'A >= ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) GeAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? >= ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LtAll returns an 'A < ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) LtAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? < ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LeAll returns an 'A <= ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f NumberField) LeAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <= ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a NumberField.
 func (f NumberField) String() string {
@@ -401,95 +531,82 @@ func (f NumberField) GetName() string {
 	return f.name
 }
 
-// // NumberFieldf follows a printf-like syntax that takes in multiple
-// // NumberFields and returns a NumberField formatted according to an arbitrary
-// // format string.  The only recognized format specifier is the ? question mark.
-// // E.g.  NumberFieldf("(? + 5 - 10) / ?", numfield1, numfield2), where the
-// // first and second question marks will be replaced with numfield1 and
-// // numfield2 respectively.
-// func NumberFieldf(format string, fields ...NumberField) NumberField {
-// 	expression := NumberField{
-// 		format: &format,
-// 		fields: make([]Field, len(fields)),
-// 	}
-// 	for i := range fields {
-// 		expression.fields[i] = fields[i]
-// 	}
-// 	return expression
-// }
-//
-// // Add will add a NumberField to a NumberField.
-// func (f NumberField) Add(field NumberField) NumberField {
-// 	return NumberFieldf("(? + ?)", f, field)
-// }
-//
-// // Sub will subtract a NumberField from a NumberField.
-// func (f NumberField) Sub(field NumberField) NumberField {
-// 	return NumberFieldf("(? - ?)", f, field)
-// }
-//
-// // Mul will mulitply a NumberField with a NumberField.
-// func (f NumberField) Mul(field NumberField) NumberField {
-// 	return NumberFieldf("(? * ?)", f, field)
-// }
-//
-// // Div will divide a NumberField by a NumberField.
-// func (f NumberField) Div(field NumberField) NumberField {
-// 	return NumberFieldf("(? / ?)", f, field)
-// }
-//
-// // Mod will modulo a NumberField by a NumberField. Note that modulo is an
-// // operation that is only defined for integers.
-// func (f NumberField) Mod(field NumberField) NumberField {
-// 	return NumberFieldf("(? % ?)", f, field)
-// }
-//
-// // Abs will return the absolute value of a NumberField.
-// func (f NumberField) Abs() NumberField {
-// 	return NumberFieldf("abs(?)", f)
-// }
-//
-// // Ceil will round a NumberField up to the nearest integer value.
-// func (f NumberField) Ceil() NumberField {
-// 	return NumberFieldf("ceil(?)", f)
-// }
-//
-// // Ceil will round a NumberField down to the nearest integer value.
-// func (f NumberField) Floor() NumberField {
-// 	return NumberFieldf("floor(?)", f)
-// }
-//
-// // Pow will return a NumberField raised to the power of another NumberField.
-// func (f NumberField) Pow(field NumberField) NumberField {
-// 	return NumberFieldf("power(?, ?)", f, field)
-// }
-//
-// // Add will add an int to a NumberField.
-// func (f NumberField) AddInt(num int) NumberField {
-// 	return NumberFieldf("(? + ?)", f, Int(num))
-// }
-//
-// // Sub will subtract an int from a NumberField.
-// func (f NumberField) SubInt(num int) NumberField {
-// 	return NumberFieldf("(? - ?)", f, Int(num))
-// }
-//
-// // Mul will multiple a NumberField by an int.
-// func (f NumberField) MulInt(num int) NumberField {
-// 	return NumberFieldf("(? * ?)", f, Int(num))
-// }
-//
-// // Div will divide a NumberField by an int.
-// func (f NumberField) DivInt(num int) NumberField {
-// 	return NumberFieldf("(? / ?)", f, Int(num))
-// }
-//
-// // Mod will modulo a NumberField by an int.
-// func (f NumberField) ModInt(num int) NumberField {
-// 	return NumberFieldf("(? % ?)", f, Int(num))
-// }
-//
-// // PowInt will raise a NumberField to the power of an int.
-// func (f NumberField) PowInt(num int) NumberField {
-// 	return NumberFieldf("power(?, ?)", f, Int(num))
-// }
+// NumberFieldf follows a printf-like syntax that takes in multiple values and
+// returns a NumberField formatted according to an arbitrary format string. The
+// only recognized format specifier is the ? question mark. E.g.
+// NumberFieldf("(? + 5 - 10) / ?", numfield1, numfield2), where the first and
+// second question marks will be replaced with numfield1 and numfield2
+// respectively. Values are interpolated as described in FormatPreprocessor.
+func NumberFieldf(format string, values ...interface{}) NumberField {
+	return NumberField{
+		format: &format,
+		values: values,
+	}
+}
+
+// Add returns a new NumberField representing 'A + B'. B can be a NumberField or
+// a Go number.
+func (f NumberField) Add(v interface{}) NumberField {
+	return NumberFieldf("(? + ?)", f, v)
+}
+
+// Sub returns a new NumberField representing 'A - B'. B can be a NumberField
+// or a Go number.
+func (f NumberField) Sub(v interface{}) NumberField {
+	return NumberFieldf("(? - ?)", f, v)
+}
+
+// Mul returns a new NumberField representing 'A * B'. B can be a NumberField
+// or a Go number.
+func (f NumberField) Mul(v interface{}) NumberField {
+	return NumberFieldf("(? * ?)", f, v)
+}
+
+// Div returns a new NumberField representing 'A / B'. B can be a NumberField
+// or a Go number. Note that dividing an integer by an integer performs integer
+// division in postgres and sqlite3.
+func (f NumberField) Div(v interface{}) NumberField {
+	return NumberFieldf("(? / ?)", f, v)
+}
+
+// Mod returns a new NumberField representing 'A % B'. B can be a NumberField
+// or a Go number. Note that modulo is an operation that is only defined for
+// integers.
+func (f NumberField) Mod(v interface{}) NumberField {
+	return NumberFieldf("(? % ?)", f, v)
+}
+
+// Neg returns a new NumberField representing '-A'.
+func (f NumberField) Neg() NumberField {
+	return NumberFieldf("(-?)", f)
+}
+
+// Abs returns a new NumberField representing the absolute value of the
+// NumberField i.e. 'abs(A)'.
+func (f NumberField) Abs() NumberField {
+	return NumberFieldf("abs(?)", f)
+}
+
+// Round returns a new NumberField representing the NumberField rounded to n
+// decimal places i.e. 'round(A, n)'.
+func (f NumberField) Round(n int) NumberField {
+	return NumberFieldf("round(?, "+strconv.Itoa(n)+")", f)
+}
+
+// Floor returns a new NumberField representing the NumberField rounded down
+// to the nearest integer value i.e. 'floor(A)'.
+func (f NumberField) Floor() NumberField {
+	return NumberFieldf("floor(?)", f)
+}
+
+// Ceil returns a new NumberField representing the NumberField rounded up to
+// the nearest integer value i.e. 'ceil(A)'.
+func (f NumberField) Ceil() NumberField {
+	return NumberFieldf("ceil(?)", f)
+}
+
+// Power returns a new NumberField representing the NumberField raised to the
+// power of B i.e. 'power(A, B)'. B can be a NumberField or a Go number.
+func (f NumberField) Power(v interface{}) NumberField {
+	return NumberFieldf("power(?, ?)", f, v)
+}
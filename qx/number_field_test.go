@@ -0,0 +1,48 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestNumberField_Arithmetic(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	pay := PAYMENT()
+	tests := []TT{
+		{"Add NumberField", pay.AMOUNT.Add(pay.STAFF_ID), "(payment.amount + payment.staff_id)", nil},
+		{"Sub int", pay.AMOUNT.Sub(2), "(payment.amount - ?)", []interface{}{2}},
+		{"Mul with alias", pay.AMOUNT.Mul(pay.STAFF_ID).As("total"), "(payment.amount * payment.staff_id)", nil},
+		{"Div float64", pay.AMOUNT.Div(2.5), "(payment.amount / ?)", []interface{}{2.5}},
+		{"Mod", pay.PAYMENT_ID.Mod(2), "(payment.payment_id % ?)", []interface{}{2}},
+		{"Neg", pay.AMOUNT.Neg(), "(-payment.amount)", nil},
+		{"Abs", pay.AMOUNT.Sub(10).Abs(), "abs((payment.amount - ?))", []interface{}{10}},
+		{"Round", pay.AMOUNT.Round(1).Desc(), "round(payment.amount, 1) DESC", nil},
+		{"Floor", pay.AMOUNT.Floor(), "floor(payment.amount)", nil},
+		{"Ceil", pay.AMOUNT.Ceil(), "ceil(payment.amount)", nil},
+		{"Power", pay.AMOUNT.Power(Int(2)), "power(payment.amount, ?)", []interface{}{2}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.f.ToSQLExclude(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestNumberField_ArithmeticPredicate(t *testing.T) {
+	is := is.New(t)
+	pay := PAYMENT()
+	query, args := pay.AMOUNT.Add(pay.STAFF_ID).GtInt(10).ToSQLExclude(nil)
+	is.Equal("(payment.amount + payment.staff_id) > ?", query)
+	is.Equal([]interface{}{10}, args)
+}
@@ -21,6 +21,22 @@ func (p CustomPredicate) ToSQLExclude(excludeTableQualifiers []string) (string,
 // AssertPredicate implements the Predicate interface.
 func (p CustomPredicate) AssertPredicate() {}
 
+// Exists returns an 'EXISTS (query)' Predicate.
+func Exists(query Query) Predicate {
+	return CustomPredicate{
+		Format: "EXISTS (?)",
+		Values: []interface{}{query},
+	}
+}
+
+// NotExists returns a 'NOT EXISTS (query)' Predicate.
+func NotExists(query Query) Predicate {
+	return CustomPredicate{
+		Format: "NOT EXISTS (?)",
+		Values: []interface{}{query},
+	}
+}
+
 // VariadicPredicateOperator is an operator that can join a variadic number of
 // Predicates together.
 type VariadicPredicateOperator string
@@ -157,6 +173,12 @@ const (
 	PredicateNotILike          BinaryPredicateOperator = "NOT ILIKE"
 	PredicateIsDistinctFrom    BinaryPredicateOperator = "IS DISTINCT FROM"
 	PredicateIsNotDistinctFrom BinaryPredicateOperator = "IS NOT DISTINCT FROM"
+	PredicateRegexMatch        BinaryPredicateOperator = "~"
+	PredicateRegexIMatch       BinaryPredicateOperator = "~*"
+	PredicateNotRegexMatch     BinaryPredicateOperator = "!~"
+	PredicateNotRegexIMatch    BinaryPredicateOperator = "!~*"
+	PredicateSimilarTo         BinaryPredicateOperator = "SIMILAR TO"
+	PredicateNotSimilarTo      BinaryPredicateOperator = "NOT SIMILAR TO"
 )
 
 // BinaryPredicate represents the 'A [operator] B' SQL construct, where
@@ -0,0 +1,64 @@
+package qx
+
+import (
+	"strings"
+)
+
+// RowLock represents an SQL row locking clause i.e. 'FOR UPDATE [OF tables]
+// [NOWAIT | SKIP LOCKED]'.
+type RowLock struct {
+	Strength   LockStrength
+	Of         []Table
+	WaitPolicy LockWaitPolicy
+}
+
+// ToSQL marshals a RowLock into an SQL query. Tables in the OF list are
+// referred to by their alias if they have one, otherwise by their name.
+func (l RowLock) ToSQL() string {
+	if l.Strength == "" {
+		return ""
+	}
+	buf := &strings.Builder{}
+	buf.WriteString(string(l.Strength))
+	if len(l.Of) > 0 {
+		names := make([]string, 0, len(l.Of))
+		for _, table := range l.Of {
+			if table == nil {
+				continue
+			}
+			if alias := table.GetAlias(); alias != "" {
+				names = append(names, alias)
+			} else {
+				names = append(names, QuoteIdentifier(table.GetName()))
+			}
+		}
+		if len(names) > 0 {
+			buf.WriteString(" OF " + strings.Join(names, ", "))
+		}
+	}
+	if l.WaitPolicy != "" {
+		buf.WriteString(" " + string(l.WaitPolicy))
+	}
+	return buf.String()
+}
+
+// RowLocks represents a list of row locking clauses.
+type RowLocks []RowLock
+
+// WriteSQL will write the row locking clauses into the buffer. If there are
+// no RowLocks to be written, it will simply write nothing. It returns a flag
+// indicating whether it wrote anything into the buffer.
+func (ls RowLocks) WriteSQL(buf *strings.Builder) (written bool) {
+	for i := range ls {
+		lockQuery := ls[i].ToSQL()
+		if lockQuery == "" {
+			continue
+		}
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(lockQuery)
+		written = true
+	}
+	return written
+}
@@ -1,23 +1,38 @@
 package qx
 
+import (
+	"strconv"
+	"strings"
+)
+
 type EnumField = StringField
 
 func NewEnumField(name string, table Table) EnumField {
 	return NewStringField(name, table)
 }
 
-// StringField either represents a string column or a literal string value.
+// StringField either represents a string column, a string expression or a
+// literal string value.
 type StringField struct {
 	// StringField will be one of the following:
 
-	// 1) Literal string value
+	// 1) String expression
+	// Examples of string expressions:
+	// | query                 | args |
+	// |-----------------------|------|
+	// | UPPER(users.name)     |      |
+	// | CONCAT(?, users.name) | Dr.  |
+	format *string
+	values []interface{}
+
+	// 2) Literal string value
 	// Examples of literal string values:
 	// | query | args |
 	// |-------|------|
 	// | ?     | abcd |
 	value *string
 
-	// 2) String column
+	// 3) String column
 	// Examples of boolean columns:
 	// | query       | args |
 	// |-------------|------|
@@ -36,27 +51,44 @@ type StringField struct {
 // appears in the excludeTableQualifiers list, the output column name will not
 // be table qualified.
 func (f StringField) ToSQLExclude(excludeTableQualifiers []string) (string, []interface{}) {
-	// 1) Literal string value
+	// 1) String expression
+	if f.format != nil {
+		return CustomField{
+			Alias:        f.alias,
+			Format:       *f.format,
+			Values:       f.values,
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
+		}.ToSQLExclude(excludeTableQualifiers)
+	}
+
+	// 2) Literal string value
 	if f.value != nil {
 		return "?", []interface{}{*f.value}
 	}
 
-	// 2) String column
+	// 3) String column
 	var tableQualifier string
 	if f.table != nil {
 		if f.table.GetAlias() != "" {
-			tableQualifier = f.table.GetAlias() + "."
+			tableQualifier = f.table.GetAlias()
 		} else if f.table.GetName() != "" {
-			tableQualifier = f.table.GetName() + "."
+			tableQualifier = f.table.GetName()
 		}
 	}
 	for i := range excludeTableQualifiers {
-		if tableQualifier == excludeTableQualifiers[i]+"." {
+		if tableQualifier == excludeTableQualifiers[i] {
 			tableQualifier = ""
 			break
 		}
 	}
-	columnName := tableQualifier + f.name
+	columnName := QuoteIdentifier(f.name)
+	if tableQualifier != "" {
+		if f.table.GetAlias() == "" {
+			tableQualifier = QuoteIdentifier(tableQualifier)
+		}
+		columnName = tableQualifier + "." + columnName
+	}
 	if f.descending != nil {
 		if *f.descending {
 			columnName = columnName + " DESC"
@@ -82,6 +114,18 @@ func NewStringField(name string, table Table) StringField {
 	}
 }
 
+// StringFieldf follows a printf-like syntax that takes in multiple values and
+// returns a StringField formatted according to an arbitrary format string. The
+// only recognized format specifier is the ? question mark. E.g.
+// StringFieldf("UPPER(?)", field). Values are interpolated as described in
+// FormatPreprocessor.
+func StringFieldf(format string, values ...interface{}) StringField {
+	return StringField{
+		format: &format,
+		values: values,
+	}
+}
+
 // String returns a new StringField representing a literal string value.
 func String(s string) StringField {
 	return StringField{
@@ -287,6 +331,25 @@ func (f StringField) NotLikeString(s string) Predicate {
 	}
 }
 
+// LikeEscaped returns an 'A LIKE B ESCAPE C' Predicate, where C is the
+// backslash used by EscapeLike. It is needed for sqlite3 which has no default
+// escape character.
+func (f StringField) LikeEscaped(s string) Predicate {
+	return CustomPredicate{
+		Format: "? LIKE ? ESCAPE ?",
+		Values: []interface{}{f, s, `\`},
+	}
+}
+
+// NotLikeEscaped returns an 'A NOT LIKE B ESCAPE C' Predicate, where C is the
+// backslash used by EscapeLike.
+func (f StringField) NotLikeEscaped(s string) Predicate {
+	return CustomPredicate{
+		Format: "? NOT LIKE ? ESCAPE ?",
+		Values: []interface{}{f, s, `\`},
+	}
+}
+
 // ILikeString returns an 'A ILIKE B' Predicate. It only accepts string.
 func (f StringField) ILikeString(s string) Predicate {
 	return BinaryPredicate{
@@ -305,6 +368,84 @@ func (f StringField) NotILikeString(s string) Predicate {
 	}
 }
 
+// LikeAny returns an 'A LIKE ANY (ARRAY[patterns])' Predicate (postgres
+// only).
+func (f StringField) LikeAny(patterns []string) Predicate {
+	format, values := textArray(patterns)
+	return CustomPredicate{
+		Format: "? LIKE ANY (" + format + ")",
+		Values: append([]interface{}{f}, values...),
+	}
+}
+
+// ILikeAny returns an 'A ILIKE ANY (ARRAY[patterns])' Predicate (postgres
+// only).
+func (f StringField) ILikeAny(patterns []string) Predicate {
+	format, values := textArray(patterns)
+	return CustomPredicate{
+		Format: "? ILIKE ANY (" + format + ")",
+		Values: append([]interface{}{f}, values...),
+	}
+}
+
+// Matches returns an 'A ~ B' Predicate, where B is a POSIX regular expression
+// (postgres only).
+func (f StringField) Matches(pattern string) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateRegexMatch,
+		LeftField:  f,
+		RightField: String(pattern),
+	}
+}
+
+// IMatches returns an 'A ~* B' Predicate, where B is a case insensitive POSIX
+// regular expression (postgres only).
+func (f StringField) IMatches(pattern string) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateRegexIMatch,
+		LeftField:  f,
+		RightField: String(pattern),
+	}
+}
+
+// NotMatches returns an 'A !~ B' Predicate, where B is a POSIX regular
+// expression (postgres only).
+func (f StringField) NotMatches(pattern string) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateNotRegexMatch,
+		LeftField:  f,
+		RightField: String(pattern),
+	}
+}
+
+// NotIMatches returns an 'A !~* B' Predicate, where B is a case insensitive
+// POSIX regular expression (postgres only).
+func (f StringField) NotIMatches(pattern string) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateNotRegexIMatch,
+		LeftField:  f,
+		RightField: String(pattern),
+	}
+}
+
+// SimilarTo returns an 'A SIMILAR TO B' Predicate (postgres only).
+func (f StringField) SimilarTo(pattern string) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateSimilarTo,
+		LeftField:  f,
+		RightField: String(pattern),
+	}
+}
+
+// NotSimilarTo returns an 'A NOT SIMILAR TO B' Predicate (postgres only).
+func (f StringField) NotSimilarTo(pattern string) Predicate {
+	return BinaryPredicate{
+		Operator:   PredicateNotSimilarTo,
+		LeftField:  f,
+		RightField: String(pattern),
+	}
+}
+
 // In returns an 'A IN (B)' Predicate, where B can be anything.
 func (f StringField) In(v interface{}) Predicate {
 	return CustomPredicate{
@@ -313,6 +454,184 @@ func (f StringField) In(v interface{}) Predicate {
 	}
 }
 
+// NotIn returns an 'A NOT IN (B)' Predicate, where B can be anything.
+func (f StringField) NotIn(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? NOT IN (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// InQuery returns an 'A IN (subquery)' Predicate.
+func (f StringField) InQuery(subquery Query) Predicate {
+	return CustomPredicate{
+		Format: "? IN (?)",
+		Values: []interface{}{f, subquery},
+	}
+}
+
+// EqAny returns an 'A = ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) EqAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? = ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// NeAny returns an 'A <> ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) NeAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <> ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GtAny returns an 'A > ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) GtAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? > ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GeAny returns an 'A >= ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) GeAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? >= ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LtAny returns an 'A < ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) LtAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? < ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LeAny returns an 'A <= ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) LeAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <= ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// EqAll returns an 'A = ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) EqAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? = ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// NeAll returns an 'A <> ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) NeAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <> ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GtAll returns an 'A > ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) GtAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? > ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GeAll returns an 'A >= ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) GeAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? >= ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LtAll returns an 'A < ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) LtAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? < ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LeAll returns an 'A <= ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f StringField) LeAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <= ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// Concat returns a new StringField representing the StringField concatenated
+// with the values i.e. 'concat(A, values...)'. The values can be Fields or Go
+// values.
+func (f StringField) Concat(values ...interface{}) StringField {
+	format := "concat(?" + strings.Repeat(", ?", len(values)) + ")"
+	return StringFieldf(format, append([]interface{}{f}, values...)...)
+}
+
+// Lower returns a new StringField representing 'lower(A)'.
+func (f StringField) Lower() StringField {
+	return StringFieldf("lower(?)", f)
+}
+
+// Upper returns a new StringField representing 'upper(A)'.
+func (f StringField) Upper() StringField {
+	return StringFieldf("upper(?)", f)
+}
+
+// Trim returns a new StringField representing 'trim(A)'.
+func (f StringField) Trim() StringField {
+	return StringFieldf("trim(?)", f)
+}
+
+// Substring returns a new StringField representing the substring of length
+// characters starting at the start-th (starting from 1) character i.e.
+// 'substr(A, start, length)'.
+func (f StringField) Substring(start, length int) StringField {
+	return StringFieldf("substr(?, "+strconv.Itoa(start)+", "+strconv.Itoa(length)+")", f)
+}
+
+// Replace returns a new StringField representing the StringField with all
+// occurrences of from replaced by to i.e. 'replace(A, from, to)'.
+func (f StringField) Replace(from, to string) StringField {
+	return StringFieldf("replace(?, ?, ?)", f, from, to)
+}
+
+// Left returns a new StringField representing the first n characters i.e.
+// 'left(A, n)' (postgres and mysql only).
+func (f StringField) Left(n int) StringField {
+	return StringFieldf("left(?, "+strconv.Itoa(n)+")", f)
+}
+
+// Right returns a new StringField representing the last n characters i.e.
+// 'right(A, n)' (postgres and mysql only).
+func (f StringField) Right(n int) StringField {
+	return StringFieldf("right(?, "+strconv.Itoa(n)+")", f)
+}
+
+// Length returns a new NumberField representing the number of characters in
+// the StringField i.e. 'char_length(A)' (postgres and mysql only).
+func (f StringField) Length() NumberField {
+	return NumberFieldf("char_length(?)", f)
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a StringField.
 func (f StringField) String() string {
@@ -0,0 +1,70 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestStringField_Functions(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	film := FILM()
+	tests := []TT{
+		{"Concat", film.TITLE.Concat(" (", film.RATING, ")").As("label"), "concat(film.title, ?, film.rating, ?)", []interface{}{" (", ")"}},
+		{"Lower", film.TITLE.Lower(), "lower(film.title)", nil},
+		{"Upper", film.TITLE.Upper().Desc(), "upper(film.title) DESC", nil},
+		{"Trim", film.TITLE.Trim(), "trim(film.title)", nil},
+		{"Substring", film.TITLE.Substring(2, 5), "substr(film.title, 2, 5)", nil},
+		{"Replace", film.TITLE.Replace("a", "b"), "replace(film.title, ?, ?)", []interface{}{"a", "b"}},
+		{"Left", film.TITLE.Left(3), "left(film.title, 3)", nil},
+		{"Right", film.TITLE.Lower().Right(3), "right(lower(film.title), 3)", nil},
+		{"Length", film.TITLE.Length(), "char_length(film.title)", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.f.ToSQLExclude(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestStringField_PatternMatching(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		p           Predicate
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	film := FILM()
+	tests := []TT{
+		{"~", film.TITLE.Matches("^A"), "film.title ~ ?", []interface{}{"^A"}},
+		{"~*", film.TITLE.IMatches("^a"), "film.title ~* ?", []interface{}{"^a"}},
+		{"!~", film.TITLE.NotMatches("^A"), "film.title !~ ?", []interface{}{"^A"}},
+		{"!~*", film.TITLE.NotIMatches("^a"), "film.title !~* ?", []interface{}{"^a"}},
+		{"SIMILAR TO", film.TITLE.SimilarTo("%(b|d)%"), "film.title SIMILAR TO ?", []interface{}{"%(b|d)%"}},
+		{"NOT SIMILAR TO", film.TITLE.NotSimilarTo("%(b|d)%"), "film.title NOT SIMILAR TO ?", []interface{}{"%(b|d)%"}},
+		{"LIKE ANY", film.TITLE.LikeAny([]string{"A%", "B%"}), "film.title LIKE ANY (ARRAY[?, ?])", []interface{}{"A%", "B%"}},
+		{"ILIKE ANY", film.TITLE.Lower().ILikeAny([]string{"%" + EscapeLike("50%") + "%"}), "lower(film.title) ILIKE ANY (ARRAY[?])", []interface{}{`%50\%%`}},
+		{"LIKE ESCAPE", film.TITLE.LikeEscaped("%" + EscapeLike("50%") + "%"), "film.title LIKE ? ESCAPE ?", []interface{}{`%50\%%`, `\`}},
+		{"NOT LIKE ESCAPE", film.TITLE.NotLikeEscaped(`A\_%`), "film.title NOT LIKE ? ESCAPE ?", []interface{}{`A\_%`, `\`}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.p.ToSQLExclude(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
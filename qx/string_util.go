@@ -21,7 +21,9 @@ import (
 // string and output args accordingly. If it's not one of the recognized types,
 // Sprintf will simply treat it as a literal argument and add a "?" to the
 // format string and the literal value to the output args. To escape a question
-// mark ?, use two question marks ?? instead.
+// mark ?, use two question marks ?? instead. Escaped question marks are kept
+// as ?? in the output, so that they are only unescaped when the toplevel query
+// is rebound (see Rebind).
 func FormatPreprocessor(format string, values []interface{}, excludeTableQualifiers []string) (string, []interface{}) {
 	var allQueries []string
 	var allArgs []interface{}
@@ -56,6 +58,8 @@ func FormatPreprocessor(format string, values []interface{}, excludeTableQualifi
 			buf := &strings.Builder{}
 			value.WriteSQL(buf, &args, "", "")
 			query = buf.String()
+		case Window:
+			query, args = value.ToSQLExclude(excludeTableQualifiers)
 		// lmao tfw no generics
 		case []int:
 			if len(value) == 0 {
@@ -121,7 +125,7 @@ func FormatPreprocessor(format string, values []interface{}, excludeTableQualifi
 	for i := strings.Index(format, "?"); i >= 0 && len(allQueries) > 0; i = strings.Index(format, "?") {
 		buf.WriteString(format[:i])
 		if len(format[i:]) > 1 && format[i:i+2] == "??" {
-			buf.WriteString("?")
+			buf.WriteString("??")
 			format = format[i+2:]
 			continue
 		}
@@ -137,27 +141,20 @@ func FormatPreprocessor(format string, values []interface{}, excludeTableQualifi
 // style incrementing placeholders i.e. $1, $2, $3 etc. To escape a literal
 // question mark ? , use two question marks ?? instead.
 func MySQLToPostgresPlaceholders(query string) string {
-	buf := &strings.Builder{}
-	i := 0
-	for {
-		p := strings.Index(query, "?")
-		if p < 0 {
-			break
-		}
-		buf.WriteString(query[:p])
-		if len(query[p:]) > 1 && query[p:p+2] == "??" {
-			buf.WriteString("?")
-			query = query[p+2:]
-		} else {
-			i++
-			buf.WriteString("$" + strconv.Itoa(i))
-			query = query[p+1:]
-		}
-	}
-	buf.WriteString(query)
-	return buf.String()
+	return rebindPlaceholders(Postgres, query)
 }
 
+// EscapeLike escapes the LIKE wildcards % and _ (as well as the backslash
+// escape character itself) in s, so that a user supplied search term can be
+// safely embedded in a LIKE pattern e.g. LikeString("%" + EscapeLike(term) +
+// "%"). Postgres and MySQL use the backslash as the default escape character,
+// sqlite3 requires an explicit ESCAPE clause i.e. LikeEscaped.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 // RandomString is the RandStringBytesMaskImprSrcSB function taken from
 // https://stackoverflow.com/a/31832326. It generates a random alphabetical
 // string of length n.
@@ -113,3 +113,9 @@ func TestInterpolateSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestEscapeLike(t *testing.T) {
+	is := is.New(t)
+	is.Equal(`100\% pure\_cotton \\ silk`, EscapeLike(`100% pure_cotton \ silk`))
+	is.Equal("plain", EscapeLike("plain"))
+}
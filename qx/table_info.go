@@ -18,15 +18,16 @@ func NewTableInfo(schema, name string) *TableInfo {
 	}
 }
 
-// ToSQL returns the fully qualified table name.
+// ToSQL returns the fully qualified table name. The schema and table name are
+// quoted if necessary (see QuoteIdentifier).
 func (tbl *TableInfo) ToSQL() (string, []interface{}) {
 	if tbl == nil {
 		return "", nil
 	}
 	if tbl.Schema == "public" {
-		return tbl.Name, nil
+		return QuoteIdentifier(tbl.Name), nil
 	}
-	return tbl.Schema + "." + tbl.Name, nil
+	return QuoteIdentifier(tbl.Schema) + "." + QuoteIdentifier(tbl.Name), nil
 }
 
 // GetAlias implements the Table interface. It returns the alias from the
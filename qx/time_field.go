@@ -1,21 +1,33 @@
 package qx
 
 import (
+	"strconv"
+	"strings"
 	"time"
 )
 
-// TimeField either represents a time column or a literal time.Time value.
+// TimeField either represents a time column, a time expression or a literal
+// time.Time value.
 type TimeField struct {
 	// TimeField will be one of the following:
 
-	// 1) Literal time.Time value
+	// 1) Time expression
+	// Examples of time expressions:
+	// | query                    | args |
+	// |--------------------------|------|
+	// | DATE_TRUNC(?, events.at) | day  |
+	// | COALESCE(a.at, b.at)     |      |
+	format *string
+	values []interface{}
+
+	// 2) Literal time.Time value
 	// Examples of literal string values:
 	// | query | args       |
 	// |-------|------------|
 	// | ?     | time.Now() |
 	value *time.Time
 
-	// 2) Time column
+	// 3) Time column
 	// Examples of time columns:
 	// | query            | args |
 	// |------------------|------|
@@ -34,27 +46,44 @@ type TimeField struct {
 // appears in the excludeTableQualifiers list, the output column name will not
 // be table qualified.
 func (f TimeField) ToSQLExclude(excludeTableQualifiers []string) (string, []interface{}) {
-	// 1) Literal time.Time value
+	// 1) Time expression
+	if f.format != nil {
+		return CustomField{
+			Alias:        f.alias,
+			Format:       *f.format,
+			Values:       f.values,
+			IsDesc:       f.descending,
+			IsNullsFirst: f.nullsfirst,
+		}.ToSQLExclude(excludeTableQualifiers)
+	}
+
+	// 2) Literal time.Time value
 	if f.value != nil {
 		return "?", []interface{}{*f.value}
 	}
 
-	// 2) Time column
+	// 3) Time column
 	var tableQualifier string
 	if f.table != nil {
 		if f.table.GetAlias() != "" {
-			tableQualifier = f.table.GetAlias() + "."
+			tableQualifier = f.table.GetAlias()
 		} else if f.table.GetName() != "" {
-			tableQualifier = f.table.GetName() + "."
+			tableQualifier = f.table.GetName()
 		}
 	}
 	for i := range excludeTableQualifiers {
-		if tableQualifier == excludeTableQualifiers[i]+"." {
+		if tableQualifier == excludeTableQualifiers[i] {
 			tableQualifier = ""
 			break
 		}
 	}
-	columnName := tableQualifier + f.name
+	columnName := QuoteIdentifier(f.name)
+	if tableQualifier != "" {
+		if f.table.GetAlias() == "" {
+			tableQualifier = QuoteIdentifier(tableQualifier)
+		}
+		columnName = tableQualifier + "." + columnName
+	}
 	if f.descending != nil {
 		if *f.descending {
 			columnName = columnName + " DESC"
@@ -80,6 +109,18 @@ func NewTimeField(name string, table Table) TimeField {
 	}
 }
 
+// TimeFieldf follows a printf-like syntax that takes in multiple values and
+// returns a TimeField formatted according to an arbitrary format string. The
+// only recognized format specifier is the ? question mark. E.g.
+// TimeFieldf("DATE_TRUNC('day', ?)", field). Values are interpolated as described in
+// FormatPreprocessor.
+func TimeFieldf(format string, values ...interface{}) TimeField {
+	return TimeField{
+		format: &format,
+		values: values,
+	}
+}
+
 // Time returns a new TimeField representing a literal time.Time value.
 func Time(t time.Time) TimeField {
 	return TimeField{
@@ -87,6 +128,18 @@ func Time(t time.Time) TimeField {
 	}
 }
 
+// Now returns a new TimeField representing the current timestamp i.e.
+// 'CURRENT_TIMESTAMP'.
+func Now() TimeField {
+	return TimeFieldf("CURRENT_TIMESTAMP")
+}
+
+// CurrentDate returns a new TimeField representing the current date i.e.
+// 'CURRENT_DATE'.
+func CurrentDate() TimeField {
+	return TimeFieldf("CURRENT_DATE")
+}
+
 // Set returns a FieldValueSet associating the TimeField to the value i.e.
 // 'SET field = value'.
 func (f TimeField) Set(value interface{}) FieldValueSet {
@@ -354,6 +407,263 @@ func (f TimeField) NotBetweenSymmetricTime(start, end time.Time) Predicate {
 	}
 }
 
+// WithinLast returns an 'A BETWEEN (CURRENT_TIMESTAMP - d) AND
+// CURRENT_TIMESTAMP' Predicate i.e. A falls within the last d duration
+// (postgres only).
+func (f TimeField) WithinLast(d time.Duration) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetween,
+		Field:    f,
+		FieldX:   Now().Sub(d),
+		FieldY:   Now(),
+	}
+}
+
+// WithinNext returns an 'A BETWEEN CURRENT_TIMESTAMP AND (CURRENT_TIMESTAMP +
+// d)' Predicate i.e. A falls within the next d duration (postgres only).
+func (f TimeField) WithinNext(d time.Duration) Predicate {
+	return TernaryPredicate{
+		Operator: PredicateBetween,
+		Field:    f,
+		FieldX:   Now(),
+		FieldY:   Now().Add(d),
+	}
+}
+
+// In returns an 'A IN (B)' Predicate, where B can be anything.
+func (f TimeField) In(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? IN (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// NotIn returns an 'A NOT IN (B)' Predicate, where B can be anything.
+func (f TimeField) NotIn(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? NOT IN (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// InQuery returns an 'A IN (subquery)' Predicate.
+func (f TimeField) InQuery(subquery Query) Predicate {
+	return CustomPredicate{
+		Format: "? IN (?)",
+		Values: []interface{}{f, subquery},
+	}
+}
+
+// EqAny returns an 'A = ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) EqAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? = ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// NeAny returns an 'A <> ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) NeAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <> ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GtAny returns an 'A > ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) GtAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? > ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GeAny returns an 'A >= ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) GeAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? >= ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LtAny returns an 'A < ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) LtAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? < ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LeAny returns an 'A <= ANY (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) LeAny(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <= ANY (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// EqAll returns an 'A = ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) EqAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? = ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// NeAll returns an 'A <> ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) NeAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <> ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GtAll returns an 'A > ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) GtAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? > ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// GeAll returns an 'A >= ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) GeAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? >= ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LtAll returns an 'A < ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) LtAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? < ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// LeAll returns an 'A <= ALL (B)' Predicate, where B is either a
+// subquery or an array.
+func (f TimeField) LeAll(v interface{}) Predicate {
+	return CustomPredicate{
+		Format: "? <= ALL (?)",
+		Values: []interface{}{f, v},
+	}
+}
+
+// Add returns a new TimeField representing 'A + interval' (postgres only).
+// The interval can be a time.Duration or a Field evaluating to an interval
+// (see Age).
+func (f TimeField) Add(interval interface{}) TimeField {
+	if d, ok := interval.(time.Duration); ok {
+		return TimeFieldf("(? + CAST(? AS INTERVAL))", f, intervalString(d))
+	}
+	return TimeFieldf("(? + ?)", f, interval)
+}
+
+// Sub returns a new TimeField representing 'A - interval' (postgres only).
+// The interval can be a time.Duration or a Field evaluating to an interval
+// (see Age).
+func (f TimeField) Sub(interval interface{}) TimeField {
+	if d, ok := interval.(time.Duration); ok {
+		return TimeFieldf("(? - CAST(? AS INTERVAL))", f, intervalString(d))
+	}
+	return TimeFieldf("(? - ?)", f, interval)
+}
+
+// DateTrunc returns a new TimeField representing the TimeField truncated to
+// the specified precision i.e. 'date_trunc('unit', A)' (postgres only). The
+// unit is written inline so that the same expression in SELECT and GROUP BY
+// is recognized as the same expression. A unit that is not one of the
+// DateParts is passed as an argument instead.
+func (f TimeField) DateTrunc(unit DatePart) TimeField {
+	if !unit.valid() {
+		return TimeFieldf("date_trunc(?, ?)", string(unit), f)
+	}
+	return TimeFieldf("date_trunc('"+string(unit)+"', ?)", f)
+}
+
+// Extract returns a new NumberField representing a subfield of the TimeField
+// i.e. 'EXTRACT(PART FROM A)'. As the part is a keyword it cannot be passed as
+// an argument. A part that is not one of the DateParts is still passed as an
+// argument, so that the database rejects it instead of it being written into
+// the query.
+func (f TimeField) Extract(part DatePart) NumberField {
+	if !part.valid() {
+		return NumberFieldf("EXTRACT(? FROM ?)", string(part), f)
+	}
+	return NumberFieldf("EXTRACT("+strings.ToUpper(string(part))+" FROM ?)", f)
+}
+
+// AtTimeZone returns a new TimeField representing the TimeField converted to
+// the specified time zone i.e. 'A AT TIME ZONE tz' (postgres only). Like
+// DateTrunc the time zone is written inline, unless it contains characters
+// that do not appear in time zone names or offsets, in which case it is passed
+// as an argument instead.
+func (f TimeField) AtTimeZone(tz string) TimeField {
+	if !isTimeZoneName(tz) {
+		return TimeFieldf("(? AT TIME ZONE ?)", f, tz)
+	}
+	return TimeFieldf("(? AT TIME ZONE '"+tz+"')", f)
+}
+
+// valid reports whether the DatePart is one of the DateParts.
+func (p DatePart) valid() bool {
+	switch p {
+	case DatePartMicroseconds, DatePartMilliseconds, DatePartSecond,
+		DatePartMinute, DatePartHour, DatePartDay, DatePartWeek, DatePartMonth,
+		DatePartQuarter, DatePartYear, DatePartDecade, DatePartCentury,
+		DatePartMillennium, DatePartDow, DatePartDoy, DatePartEpoch,
+		DatePartIsoDow, DatePartIsoYear:
+		return true
+	}
+	return false
+}
+
+// isTimeZoneName reports whether tz only contains the characters that appear
+// in time zone names (e.g. 'America/New_York') and offsets (e.g. '+08:00').
+func isTimeZoneName(tz string) bool {
+	if tz == "" {
+		return false
+	}
+	for _, c := range tz {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '/', c == '_', c == '+', c == '-', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Age returns a Field representing the interval between the TimeField and the
+// other TimeField i.e. 'age(A, other)' (postgres only). The result can be
+// passed to Add or Sub.
+func (f TimeField) Age(other TimeField) CustomField {
+	return CustomField{
+		Format: "age(?, ?)",
+		Values: []interface{}{f, other},
+	}
+}
+
+// intervalString converts a time.Duration into a string that can be cast into
+// an INTERVAL.
+func intervalString(d time.Duration) string {
+	return strconv.FormatInt(d.Microseconds(), 10) + " microseconds"
+}
+
 // String implements the fmt.Stringer interface. It returns the string
 // representation of a TimeField.
 func (f TimeField) String() string {
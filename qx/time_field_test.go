@@ -0,0 +1,54 @@
+package qx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestTimeField_Functions(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	pay := PAYMENT()
+	tests := []TT{
+		{"Now", Now(), "CURRENT_TIMESTAMP", nil},
+		{"CurrentDate", CurrentDate(), "CURRENT_DATE", nil},
+		{"Add duration", pay.PAYMENT_DATE.Add(90 * time.Minute), "(payment.payment_date + CAST(? AS INTERVAL))", []interface{}{"5400000000 microseconds"}},
+		{"Sub duration", pay.PAYMENT_DATE.Sub(time.Second), "(payment.payment_date - CAST(? AS INTERVAL))", []interface{}{"1000000 microseconds"}},
+		{"Add interval field", Now().Add(pay.PAYMENT_DATE.Age(Now())), "(CURRENT_TIMESTAMP + age(payment.payment_date, CURRENT_TIMESTAMP))", nil},
+		{"DateTrunc", pay.PAYMENT_DATE.DateTrunc("day").As("day"), "date_trunc('day', payment.payment_date)", nil},
+		{"DateTrunc invalid unit", pay.PAYMENT_DATE.DateTrunc("day'); --"), "date_trunc(?, payment.payment_date)", []interface{}{"day'); --"}},
+		{"Extract", pay.PAYMENT_DATE.Extract(DatePartYear), "EXTRACT(YEAR FROM payment.payment_date)", nil},
+		{"Extract invalid part", pay.PAYMENT_DATE.Extract("year FROM now()) --"), "EXTRACT(? FROM payment.payment_date)", []interface{}{"year FROM now()) --"}},
+		{"AtTimeZone", pay.PAYMENT_DATE.AtTimeZone("America/New_York").Desc(), "(payment.payment_date AT TIME ZONE 'America/New_York') DESC", nil},
+		{"AtTimeZone offset", pay.PAYMENT_DATE.AtTimeZone("+08:00"), "(payment.payment_date AT TIME ZONE '+08:00')", nil},
+		{"AtTimeZone invalid zone", pay.PAYMENT_DATE.AtTimeZone("UTC'"), "(payment.payment_date AT TIME ZONE ?)", []interface{}{"UTC'"}},
+		{"Age", pay.PAYMENT_DATE.Age(Now()), "age(payment.payment_date, CURRENT_TIMESTAMP)", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.f.ToSQLExclude(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestTimeField_RelativeBetween(t *testing.T) {
+	is := is.New(t)
+	pay := PAYMENT()
+	query, args := pay.PAYMENT_DATE.WithinLast(7 * 24 * time.Hour).ToSQLExclude(nil)
+	is.Equal("payment.payment_date BETWEEN (CURRENT_TIMESTAMP - CAST(? AS INTERVAL)) AND CURRENT_TIMESTAMP", query)
+	is.Equal([]interface{}{"604800000000 microseconds"}, args)
+	query, args = pay.PAYMENT_DATE.WithinNext(time.Hour).ToSQLExclude(nil)
+	is.Equal("payment.payment_date BETWEEN CURRENT_TIMESTAMP AND (CURRENT_TIMESTAMP + CAST(? AS INTERVAL))", query)
+	is.Equal([]interface{}{"3600000000 microseconds"}, args)
+}
@@ -42,3 +42,93 @@ func (vl ValuesList) WriteSQL(buf *strings.Builder, args *[]interface{}, prepend
 	}
 	return false
 }
+
+// ValuesTable represents a VALUES list used as a derived table i.e. '(VALUES
+// (a, b), (c, d)) AS alias (column1, column2)' (postgres only). It can be used
+// anywhere a Table can, such as in From, Join or the FROM clause of an
+// UPDATE query. In postgres the types of the columns are inferred from the
+// values, so it may be necessary to cast the values of the first row (see
+// Cast).
+type ValuesTable struct {
+	Alias   string
+	Columns []string
+	Rows    ValuesList
+}
+
+// Values returns a new ValuesTable containing the rows.
+func Values(rows ...[]interface{}) ValuesTable {
+	return ValuesTable{
+		Rows: rows,
+	}
+}
+
+// As returns a new ValuesTable with the new alias and column names i.e.
+// 'AS alias (columns)'.
+func (vt ValuesTable) As(alias string, columns ...string) ValuesTable {
+	vt.Alias = alias
+	vt.Columns = columns
+	return vt
+}
+
+// ToSQL marshals a ValuesTable into an SQL query and args i.e. '(VALUES (a,
+// b), (c, d))'. The alias and column names are written by the query it is
+// used in, see TableAlias.
+func (vt ValuesTable) ToSQL() (string, []interface{}) {
+	buf := &strings.Builder{}
+	var args []interface{}
+	if !vt.Rows.WriteSQL(buf, &args, "(VALUES ", ")") {
+		return "", nil
+	}
+	return buf.String(), args
+}
+
+// GetAlias implements the Table interface. It returns the Alias of the
+// ValuesTable.
+func (vt ValuesTable) GetAlias() string {
+	return vt.Alias
+}
+
+// GetName implements the Table interface. It always returns an empty string
+// because a ValuesTable does not have a name.
+func (vt ValuesTable) GetName() string {
+	return ""
+}
+
+// TableAlias returns what follows 'AS' when the table is aliased in a FROM,
+// JOIN or USING clause. It is usually just the alias, but a ValuesTable also
+// names its columns i.e. 'alias (column1, column2)'.
+func TableAlias(table Table) string {
+	alias := table.GetAlias()
+	vt, ok := table.(ValuesTable)
+	if !ok || alias == "" || len(vt.Columns) == 0 {
+		return alias
+	}
+	columns := make([]string, len(vt.Columns))
+	for i := range vt.Columns {
+		columns[i] = QuoteIdentifier(vt.Columns[i])
+	}
+	return alias + " (" + strings.Join(columns, ", ") + ")"
+}
+
+// Number returns a new NumberField representing the column of the
+// ValuesTable.
+func (vt ValuesTable) Number(column string) NumberField {
+	return NewNumberField(column, vt)
+}
+
+// String returns a new StringField representing the column of the
+// ValuesTable.
+func (vt ValuesTable) String(column string) StringField {
+	return NewStringField(column, vt)
+}
+
+// Time returns a new TimeField representing the column of the ValuesTable.
+func (vt ValuesTable) Time(column string) TimeField {
+	return NewTimeField(column, vt)
+}
+
+// Boolean returns a new BooleanField representing the column of the
+// ValuesTable.
+func (vt ValuesTable) Boolean(column string) BooleanField {
+	return NewBooleanField(column, vt)
+}
@@ -0,0 +1,184 @@
+package qx
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Window represents an SQL window definition i.e. the part that comes after
+// OVER in 'ROW_NUMBER() OVER (PARTITION BY a ORDER BY b)'. A Window with a
+// Name is a named window: it is referenced by name in an OVER clause and must
+// be defined in the WINDOW clause of the SelectQuery.
+type Window struct {
+	Name        string
+	PartitionBy Fields
+	OrderBy     Fields
+	Frame       WindowFrame
+}
+
+// WindowFrame represents the frame clause of a Window e.g. 'ROWS BETWEEN
+// UNBOUNDED PRECEDING AND CURRENT ROW'. If End is empty, only the Start of the
+// frame is written e.g. 'ROWS UNBOUNDED PRECEDING'.
+type WindowFrame struct {
+	Mode  FrameMode
+	Start string
+	End   string
+}
+
+// Preceding returns the 'n PRECEDING' frame bound.
+func Preceding(n int) string {
+	return strconv.Itoa(n) + " PRECEDING"
+}
+
+// Following returns the 'n FOLLOWING' frame bound.
+func Following(n int) string {
+	return strconv.Itoa(n) + " FOLLOWING"
+}
+
+// ToSQLExclude marshals a Window into an SQL window definition (without the
+// enclosing brackets). The list of table qualifiers to be excluded is
+// propagated down to the PartitionBy and OrderBy Fields.
+func (w Window) ToSQLExclude(excludeTableQualifiers []string) (string, []interface{}) {
+	buf := &strings.Builder{}
+	var args []interface{}
+	w.PartitionBy.WriteSQL(buf, &args, "PARTITION BY ", "", excludeTableQualifiers)
+	w.OrderBy.WriteSQL(buf, &args, "ORDER BY ", "", excludeTableQualifiers)
+	if w.Frame.Mode != "" && w.Frame.Start != "" {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		if w.Frame.End != "" {
+			buf.WriteString(string(w.Frame.Mode) + " BETWEEN " + w.Frame.Start + " AND " + w.Frame.End)
+		} else {
+			buf.WriteString(string(w.Frame.Mode) + " " + w.Frame.Start)
+		}
+	}
+	return buf.String(), args
+}
+
+// Windows represents a list of named Windows i.e. the WINDOW clause.
+type Windows []Window
+
+// WriteSQL will write the WINDOW clause into the buffer and args. Windows
+// without a Name are skipped. If there are no Windows to be written, it will
+// simply write nothing. It returns a flag indicating whether it wrote anything
+// into the buffer.
+func (ws Windows) WriteSQL(buf *strings.Builder, args *[]interface{}, excludeTableQualifiers []string) (written bool) {
+	var windowsQueries []string
+	var windowsArgs []interface{}
+	for i := range ws {
+		if ws[i].Name == "" {
+			continue
+		}
+		windowQuery, windowArgs := ws[i].ToSQLExclude(excludeTableQualifiers)
+		windowsQueries = append(windowsQueries, ws[i].Name+" AS ("+windowQuery+")")
+		windowsArgs = append(windowsArgs, windowArgs...)
+	}
+	if len(windowsQueries) > 0 {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("WINDOW " + strings.Join(windowsQueries, ", "))
+		*args = append(*args, windowsArgs...)
+		return true
+	}
+	return false
+}
+
+// over returns the OVER clause format and values for the Window.
+func (w Window) over() (string, []interface{}) {
+	if w.Name != "" {
+		return " OVER " + w.Name, nil
+	}
+	return " OVER (?)", []interface{}{w}
+}
+
+// Over returns a new NumberField representing the window function call 'field
+// OVER window'.
+func (f NumberField) Over(window Window) NumberField {
+	format, values := window.over()
+	expression := NumberFieldf("?"+format, append([]interface{}{f.unordered()}, values...)...)
+	expression.alias = f.alias
+	expression.descending = f.descending
+	expression.nullsfirst = f.nullsfirst
+	return expression
+}
+
+// unordered returns the NumberField without its ASC/DESC and NULLS FIRST/LAST
+// modifiers.
+func (f NumberField) unordered() NumberField {
+	f.descending, f.nullsfirst = nil, nil
+	return f
+}
+
+// Over returns a new CustomField representing the window function call 'field
+// OVER window'. It is useful for calling aggregate functions over a window e.g.
+// Fieldf("SUM(?)", field).Over(window).
+func (f CustomField) Over(window Window) CustomField {
+	format, values := window.over()
+	expression := CustomField{
+		Alias:        f.Alias,
+		Format:       "?" + format,
+		IsDesc:       f.IsDesc,
+		IsNullsFirst: f.IsNullsFirst,
+	}
+	f.IsDesc, f.IsNullsFirst = nil, nil
+	expression.Values = append([]interface{}{f}, values...)
+	return expression
+}
+
+// RowNumber returns the 'ROW_NUMBER()' window function.
+func RowNumber() NumberField {
+	return NumberFieldf("ROW_NUMBER()")
+}
+
+// Rank returns the 'RANK()' window function.
+func Rank() NumberField {
+	return NumberFieldf("RANK()")
+}
+
+// DenseRank returns the 'DENSE_RANK()' window function.
+func DenseRank() NumberField {
+	return NumberFieldf("DENSE_RANK()")
+}
+
+// Lag returns the 'LAG(field, n)' window function.
+func Lag(field Field, n int) NumberField {
+	return NumberFieldf("LAG(?, "+strconv.Itoa(n)+")", field)
+}
+
+// Lead returns the 'LEAD(field, n)' window function.
+func Lead(field Field, n int) NumberField {
+	return NumberFieldf("LEAD(?, "+strconv.Itoa(n)+")", field)
+}
+
+// FirstValue returns the 'FIRST_VALUE(field)' window function.
+func FirstValue(field Field) NumberField {
+	return NumberFieldf("FIRST_VALUE(?)", field)
+}
+
+// LastValue returns the 'LAST_VALUE(field)' window function.
+func LastValue(field Field) NumberField {
+	return NumberFieldf("LAST_VALUE(?)", field)
+}
+
+// NthValue returns the 'NTH_VALUE(field, n)' window function.
+func NthValue(field Field, n int) NumberField {
+	return NumberFieldf("NTH_VALUE(?, "+strconv.Itoa(n)+")", field)
+}
+
+// ContainsWindowFunction reports whether any of the fields is, or contains, a
+// window function call i.e. 'field OVER window'.
+func ContainsWindowFunction(fields ...Field) bool {
+	for i := range fields {
+		if fields[i] == nil {
+			continue
+		}
+		if containsExpression(fields[i], func(format string, v interface{}) bool {
+			return strings.Contains(format, " OVER ")
+		}) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package qx
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWindow(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		f           Field
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	p := PAYMENT()
+	tests := []TT{
+		{
+			"empty window",
+			RowNumber().Over(Window{}),
+			"ROW_NUMBER() OVER ()",
+			nil,
+		},
+		{
+			"partition by and order by",
+			Rank().Over(Window{PartitionBy: Fields{p.CUSTOMER_ID}, OrderBy: Fields{p.AMOUNT.Desc()}}),
+			"RANK() OVER (PARTITION BY payment.customer_id ORDER BY payment.amount DESC)",
+			nil,
+		},
+		{
+			"frame with start and end",
+			FirstValue(p.AMOUNT).Over(Window{
+				OrderBy: Fields{p.PAYMENT_DATE},
+				Frame:   WindowFrame{Mode: FrameModeRows, Start: Preceding(2), End: Following(1)},
+			}),
+			"FIRST_VALUE(payment.amount) OVER (ORDER BY payment.payment_date ROWS BETWEEN 2 PRECEDING AND 1 FOLLOWING)",
+			nil,
+		},
+		{
+			"frame with start only",
+			LastValue(p.AMOUNT).Over(Window{
+				OrderBy: Fields{p.PAYMENT_DATE},
+				Frame:   WindowFrame{Mode: FrameModeRange, Start: FrameUnboundedPreceding},
+			}),
+			"LAST_VALUE(payment.amount) OVER (ORDER BY payment.payment_date RANGE UNBOUNDED PRECEDING)",
+			nil,
+		},
+		{
+			"named window",
+			Lag(p.AMOUNT, 1).Over(Window{Name: "w", PartitionBy: Fields{p.CUSTOMER_ID}}),
+			"LAG(payment.amount, 1) OVER w",
+			nil,
+		},
+		{
+			"window args",
+			NthValue(p.AMOUNT, 3).Over(Window{PartitionBy: Fields{CustomField{Format: "? % ?", Values: []interface{}{p.CUSTOMER_ID, 2}}}}),
+			"NTH_VALUE(payment.amount, 3) OVER (PARTITION BY payment.customer_id % ?)",
+			[]interface{}{2},
+		},
+		{
+			"ordering is applied outside the window",
+			DenseRank().Over(Window{OrderBy: Fields{p.AMOUNT}}).Desc(),
+			"DENSE_RANK() OVER (ORDER BY payment.amount) DESC",
+			nil,
+		},
+		{
+			"aggregate over window",
+			CustomField{Format: "SUM(?)", Values: []interface{}{p.AMOUNT}}.Over(Window{PartitionBy: Fields{p.CUSTOMER_ID}}),
+			"SUM(payment.amount) OVER (PARTITION BY payment.customer_id)",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.f.ToSQLExclude(nil)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestContainsWindowFunction(t *testing.T) {
+	is := is.New(t)
+	pay := PAYMENT()
+	is.True(ContainsWindowFunction(pay.CUSTOMER_ID, RowNumber().Over(Window{Name: "w"})))
+	is.True(ContainsWindowFunction(Sum(pay.AMOUNT).Over(Window{PartitionBy: Fields{pay.CUSTOMER_ID}}).Mul(2)))
+	is.True(ContainsWindowFunction(CustomField{Format: "SUM(?)", Values: []interface{}{pay.AMOUNT}}.Over(Window{Name: "w"})))
+	is.True(!ContainsWindowFunction(pay.CUSTOMER_ID, Sum(pay.AMOUNT)))
+}
@@ -0,0 +1,279 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+type DeleteQuery struct {
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
+	// WITH
+	CTEs qx.CTEs
+	// DELETE FROM
+	FromTable  qx.BaseTable
+	JoinGroups qx.JoinTables
+	// WHERE
+	WherePredicates qx.VariadicPredicate
+	// ORDER BY
+	OrderByFields qx.Fields
+	// LIMIT
+	LimitValue *uint64
+	// DB
+	DB qx.DB
+	// Logging
+	Log     qx.Logger
+	LogFlag int
+	LogSkip int
+}
+
+func (q DeleteQuery) ToSQL() (string, []interface{}) {
+	var buf = &strings.Builder{}
+	var args []interface{}
+	// WITH
+	q.CTEs.WriteSQL(buf, &args)
+	{ // DELETE FROM
+		deleteQuery, deleteArgs := "", []interface{}{}
+		if q.FromTable != nil {
+			deleteQuery, deleteArgs = q.FromTable.ToSQL()
+		}
+		if deleteQuery != "" {
+			if buf.Len() > 0 {
+				buf.WriteString(" ")
+			}
+			// A multiple-table DELETE has to name the table that rows are
+			// deleted from i.e. DELETE t FROM t JOIN ...
+			if len(q.JoinGroups) > 0 {
+				if q.FromTable.GetAlias() != "" {
+					buf.WriteString("DELETE " + q.FromTable.GetAlias() + " ")
+				} else {
+					buf.WriteString("DELETE " + deleteQuery + " ")
+				}
+			} else {
+				buf.WriteString("DELETE ")
+			}
+			if q.FromTable.GetAlias() != "" {
+				buf.WriteString("FROM " + deleteQuery + " AS " + qx.TableAlias(q.FromTable))
+			} else {
+				buf.WriteString("FROM " + deleteQuery)
+			}
+			args = append(args, deleteArgs...)
+		}
+	}
+	// JOIN
+	q.JoinGroups.WriteSQL(buf, &args)
+	// WHERE
+	q.WherePredicates.Toplevel = true
+	q.WherePredicates.WriteSQL(buf, &args, "WHERE ", "", nil)
+	// ORDER BY
+	q.OrderByFields.WriteSQL(buf, &args, "ORDER BY ", "", nil)
+	// LIMIT
+	if q.LimitValue != nil {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("LIMIT ?")
+		args = append(args, *q.LimitValue)
+	}
+	query := buf.String()
+	if !q.Nested {
+		if q.Dialect == nil {
+			q.Dialect = qx.MySQL
+		}
+		query = qx.Rebind(q.Dialect, query)
+		if q.Log != nil {
+			var logOutput string
+			switch {
+			case LStats&q.LogFlag != 0:
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
+			case LInterpolate&q.LogFlag != 0:
+				logOutput = "Executing query: " + q.Dialect.InterpolateSQL(query, args...)
+			default:
+				logOutput = "Executing query: " + query + " " + fmt.Sprint(args)
+			}
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logOutput)
+			default:
+				q.Log.Output(q.LogSkip+1, logOutput)
+			}
+		}
+	}
+	return query, args
+}
+
+// ToSQLDialect marshals the DeleteQuery into an SQL query for the given dialect.
+func (q DeleteQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
+func (q DeleteQuery) GetAlias() string {
+	return q.Alias
+}
+
+func (q DeleteQuery) GetName() string {
+	return ""
+}
+
+func (q DeleteQuery) NestThis() qx.Query {
+	q.Nested = true
+	return q
+}
+
+func (q DeleteQuery) As(alias string) DeleteQuery {
+	q.Alias = alias
+	return q
+}
+
+func DeleteFrom(table qx.BaseTable) DeleteQuery {
+	return DeleteQuery{
+		FromTable: table,
+		Alias:     qx.RandomString(8),
+	}
+}
+
+func (q DeleteQuery) With(cteList ...qx.CTE) DeleteQuery {
+	q.CTEs = append(q.CTEs, cteList...)
+	return q
+}
+
+func (q DeleteQuery) DeleteFrom(tbl qx.BaseTable) DeleteQuery {
+	q.FromTable = tbl
+	return q
+}
+
+func (q DeleteQuery) Join(tbl qx.Table, predicate qx.Predicate, predicates ...qx.Predicate) DeleteQuery {
+	predicates = append([]qx.Predicate{predicate}, predicates...)
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeDefault,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: predicates},
+	})
+	return q
+}
+
+func (q DeleteQuery) LeftJoin(tbl qx.Table, predicate qx.Predicate, predicates ...qx.Predicate) DeleteQuery {
+	predicates = append([]qx.Predicate{predicate}, predicates...)
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeLeft,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: predicates},
+	})
+	return q
+}
+
+func (q DeleteQuery) RightJoin(tbl qx.Table, predicate qx.Predicate, predicates ...qx.Predicate) DeleteQuery {
+	predicates = append([]qx.Predicate{predicate}, predicates...)
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeRight,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: predicates},
+	})
+	return q
+}
+
+func (q DeleteQuery) CrossJoin(tbl qx.Table) DeleteQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType: qx.JoinTypeCross,
+		Table:    tbl,
+	})
+	return q
+}
+
+func (q DeleteQuery) JoinLateral(tbl qx.Table, predicates ...qx.Predicate) DeleteQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeDefault,
+		Lateral:      true,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: predicates},
+	})
+	return q
+}
+
+func (q DeleteQuery) LeftJoinLateral(tbl qx.Table, predicates ...qx.Predicate) DeleteQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeLeft,
+		Lateral:      true,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: predicates},
+	})
+	return q
+}
+
+func (q DeleteQuery) JoinUsing(tbl qx.Table, fields ...qx.Field) DeleteQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:    qx.JoinTypeDefault,
+		Table:       tbl,
+		UsingFields: fields,
+	})
+	return q
+}
+
+func (q DeleteQuery) NaturalJoin(tbl qx.Table) DeleteQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType: qx.JoinTypeNatural,
+		Table:    tbl,
+	})
+	return q
+}
+
+func (q DeleteQuery) Where(predicates ...qx.Predicate) DeleteQuery {
+	q.WherePredicates.Predicates = append(q.WherePredicates.Predicates, predicates...)
+	return q
+}
+
+func (q DeleteQuery) OrderBy(fields ...qx.Field) DeleteQuery {
+	q.OrderByFields = append(q.OrderByFields, fields...)
+	return q
+}
+
+func (q DeleteQuery) Limit(limit int) DeleteQuery {
+	if limit < 0 {
+		limit = -limit
+	}
+	num := uint64(limit)
+	q.LimitValue = &num
+	return q
+}
+
+func (q DeleteQuery) Exec(db qx.DB) (sql.Result, error) {
+	q.LogSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+func (q DeleteQuery) ExecContext(ctx context.Context, db qx.DB) (res sql.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	if db == nil {
+		if q.DB == nil {
+			return res, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		res, err = db.Exec(query, args...)
+	} else {
+		res, err = db.ExecContext(ctx, query, args...)
+	}
+	return res, err
+}
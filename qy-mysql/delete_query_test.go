@@ -0,0 +1,61 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDeleteQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           DeleteQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "single-table delete with order by and limit"
+			actor := ACTOR()
+			q := DeleteFrom(actor).
+				Where(actor.FIRST_NAME.EqString("PENELOPE")).
+				OrderBy(actor.LAST_UPDATE.Desc()).
+				Limit(5)
+			wantQuery := "DELETE FROM actor WHERE actor.first_name = ? ORDER BY actor.last_update DESC LIMIT ?"
+			wantArgs := []interface{}{"PENELOPE", uint64(5)}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "multiple-table delete"
+			film_actor, actor := FILM_ACTOR(), ACTOR()
+			q := DeleteFrom(film_actor).
+				Join(actor, actor.ACTOR_ID.Eq(film_actor.ACTOR_ID)).
+				Where(actor.FIRST_NAME.EqString("PENELOPE"))
+			wantQuery := "DELETE film_actor FROM film_actor" +
+				" JOIN actor ON actor.actor_id = film_actor.actor_id" +
+				" WHERE actor.first_name = ?"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{"PENELOPE"}}
+		}(),
+		func() TT {
+			DESCRIPTION := "multiple-table delete with alias"
+			fa, actor := FILM_ACTOR().As("fa"), ACTOR().As("a")
+			q := DeleteFrom(fa).
+				LeftJoin(actor, actor.ACTOR_ID.Eq(fa.ACTOR_ID)).
+				Where(actor.ACTOR_ID.IsNull())
+			wantQuery := "DELETE fa FROM film_actor AS fa" +
+				" LEFT JOIN actor AS a ON a.actor_id = fa.actor_id" +
+				" WHERE a.actor_id IS NULL"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
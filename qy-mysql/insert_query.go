@@ -0,0 +1,240 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+type InsertQuery struct {
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
+	// WITH
+	CTEs qx.CTEs
+	// INSERT INTO
+	Ignore       bool
+	IntoTable    qx.BaseTable
+	InsertFields qx.Fields
+	// VALUES
+	ValuesList qx.ValuesList
+	// SELECT
+	SelectQuery *SelectQuery
+	// ON DUPLICATE KEY UPDATE
+	Resolution qx.FieldValueSets
+	// DB
+	DB qx.DB
+	// Logging
+	Log     qx.Logger
+	LogFlag int
+	LogSkip int
+}
+
+func (q InsertQuery) ToSQL() (string, []interface{}) {
+	var buf = &strings.Builder{}
+	var args []interface{}
+	var excludeTableQualifiers []string
+	{ // INSERT INTO
+		intoQuery, intoArgs := "", []interface{}{}
+		if q.IntoTable != nil {
+			intoQuery, intoArgs = q.IntoTable.ToSQL()
+			// MySQL does not allow the INSERT table to be aliased, so both the
+			// alias and the name have to be excluded from the table qualifiers
+			if q.IntoTable.GetAlias() != "" {
+				excludeTableQualifiers = append(excludeTableQualifiers, q.IntoTable.GetAlias())
+			}
+			if q.IntoTable.GetName() != "" {
+				excludeTableQualifiers = append(excludeTableQualifiers, q.IntoTable.GetName())
+			}
+		}
+		if intoQuery != "" {
+			if q.Ignore {
+				buf.WriteString("INSERT IGNORE INTO " + intoQuery)
+			} else {
+				buf.WriteString("INSERT INTO " + intoQuery)
+			}
+			args = append(args, intoArgs...)
+			q.InsertFields.WriteSQL(buf, &args, "(", ")", excludeTableQualifiers)
+		}
+	}
+	// VALUES/SELECT
+	switch {
+	case len(q.ValuesList) > 0:
+		q.ValuesList.WriteSQL(buf, &args, "VALUES ", "")
+	case q.SelectQuery != nil:
+		// MySQL only accepts the WITH clause in front of the SELECT part of an
+		// INSERT ... SELECT
+		selectQ := *q.SelectQuery
+		selectQ.CTEs = append(append(qx.CTEs{}, q.CTEs...), selectQ.CTEs...)
+		selectQ.Nested = true
+		selectQuery, selectArgs := selectQ.ToSQL()
+		if selectQuery != "" {
+			if buf.Len() > 0 {
+				buf.WriteString(" ")
+			}
+			buf.WriteString(selectQuery)
+			args = append(args, selectArgs...)
+		}
+	}
+	// ON DUPLICATE KEY UPDATE
+	q.Resolution.WriteSQL(buf, &args, "ON DUPLICATE KEY UPDATE ", "", excludeTableQualifiers)
+	query := buf.String()
+	if !q.Nested {
+		if q.Dialect == nil {
+			q.Dialect = qx.MySQL
+		}
+		query = qx.Rebind(q.Dialect, query)
+		if q.Log != nil {
+			var logOutput string
+			switch {
+			case LStats&q.LogFlag != 0:
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
+			case LInterpolate&q.LogFlag != 0:
+				logOutput = "Executing query: " + q.Dialect.InterpolateSQL(query, args...)
+			default:
+				logOutput = "Executing query: " + query + " " + fmt.Sprint(args)
+			}
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logOutput)
+			default:
+				q.Log.Output(q.LogSkip+1, logOutput)
+			}
+		}
+	}
+	return query, args
+}
+
+// ToSQLDialect marshals the InsertQuery into an SQL query for the given dialect.
+func (q InsertQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
+func InsertInto(table qx.BaseTable) InsertQuery {
+	return InsertQuery{
+		IntoTable: table,
+		Alias:     qx.RandomString(8),
+	}
+}
+
+func InsertIgnoreInto(table qx.BaseTable) InsertQuery {
+	return InsertQuery{
+		Ignore:    true,
+		IntoTable: table,
+		Alias:     qx.RandomString(8),
+	}
+}
+
+func (q InsertQuery) With(ctes ...qx.CTE) InsertQuery {
+	q.CTEs = append(q.CTEs, ctes...)
+	return q
+}
+
+func (q InsertQuery) InsertInto(table qx.BaseTable) InsertQuery {
+	q.IntoTable = table
+	return q
+}
+
+func (q InsertQuery) InsertIgnoreInto(table qx.BaseTable) InsertQuery {
+	q.Ignore = true
+	q.IntoTable = table
+	return q
+}
+
+func (q InsertQuery) Columns(fields ...qx.Field) InsertQuery {
+	q.InsertFields = append(q.InsertFields, fields...)
+	return q
+}
+
+func (q InsertQuery) Values(values ...interface{}) InsertQuery {
+	q.ValuesList = append(q.ValuesList, values)
+	return q
+}
+
+func (q InsertQuery) InsertRow(sets ...qx.FieldValueSet) InsertQuery {
+	fields, values := make([]qx.Field, len(sets)), make([]interface{}, len(sets))
+	for i := range sets {
+		fields[i] = sets[i].Field
+		values[i] = sets[i].Value
+	}
+	if len(q.InsertFields) == 0 {
+		q.InsertFields = fields
+	}
+	q.ValuesList = append(q.ValuesList, values)
+	return q
+}
+
+func (q InsertQuery) Select(selectQuery SelectQuery) InsertQuery {
+	q.SelectQuery = &selectQuery
+	return q
+}
+
+func (q InsertQuery) OnDuplicateKeyUpdate(sets ...qx.FieldValueSet) InsertQuery {
+	q.Resolution = append(q.Resolution, sets...)
+	return q
+}
+
+// Values returns the VALUES(field) function, which in an ON DUPLICATE KEY
+// UPDATE clause refers to the value that would have been inserted into that
+// field.
+func Values(field qx.Field) qx.CustomField {
+	return qx.CustomField{Format: "VALUES(" + qx.QuoteIdentifier(field.GetName()) + ")"}
+}
+
+func (q InsertQuery) Exec(db qx.DB) (sql.Result, error) {
+	q.LogSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+func (q InsertQuery) ExecContext(ctx context.Context, db qx.DB) (res sql.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	if db == nil {
+		if q.DB == nil {
+			return res, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		res, err = db.Exec(query, args...)
+	} else {
+		res, err = db.ExecContext(ctx, query, args...)
+	}
+	return res, err
+}
+
+func (q InsertQuery) As(alias string) InsertQuery {
+	q.Alias = alias
+	return q
+}
+
+func (q InsertQuery) GetAlias() string {
+	return q.Alias
+}
+
+func (q InsertQuery) GetName() string {
+	return ""
+}
+
+func (q InsertQuery) NestThis() qx.Query {
+	q.Nested = true
+	return q
+}
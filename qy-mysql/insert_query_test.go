@@ -0,0 +1,88 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestInsertQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           InsertQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "basic insert with multiple values"
+			actor := ACTOR()
+			q := InsertInto(actor).
+				Columns(actor.FIRST_NAME, actor.LAST_NAME).
+				Values("PENELOPE", "GUINESS").
+				Values("NICK", "WAHLBERG")
+			wantQuery := "INSERT INTO actor (first_name, last_name) VALUES (?, ?), (?, ?)"
+			wantArgs := []interface{}{"PENELOPE", "GUINESS", "NICK", "WAHLBERG"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "aliased table is not aliased in the INSERT"
+			actor := ACTOR().As("a")
+			q := InsertInto(actor).
+				InsertRow(
+					actor.FIRST_NAME.Set("PENELOPE"),
+					actor.LAST_NAME.Set("GUINESS"),
+				)
+			wantQuery := "INSERT INTO actor (first_name, last_name) VALUES (?, ?)"
+			wantArgs := []interface{}{"PENELOPE", "GUINESS"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "INSERT IGNORE"
+			actor := ACTOR()
+			q := InsertIgnoreInto(actor).
+				Columns(actor.ACTOR_ID, actor.FIRST_NAME).
+				Values(1, "PENELOPE")
+			wantQuery := "INSERT IGNORE INTO actor (actor_id, first_name) VALUES (?, ?)"
+			wantArgs := []interface{}{1, "PENELOPE"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "ON DUPLICATE KEY UPDATE"
+			actor := ACTOR().As("a")
+			q := InsertInto(actor).
+				Columns(actor.ACTOR_ID, actor.FIRST_NAME, actor.LAST_NAME).
+				Values(1, "PENELOPE", "GUINESS").
+				OnDuplicateKeyUpdate(
+					actor.FIRST_NAME.Set(Values(actor.FIRST_NAME)),
+					actor.LAST_NAME.Set("GUINESS"),
+				)
+			wantQuery := "INSERT INTO actor (actor_id, first_name, last_name) VALUES (?, ?, ?)" +
+				" ON DUPLICATE KEY UPDATE first_name = VALUES(first_name), last_name = ?"
+			wantArgs := []interface{}{1, "PENELOPE", "GUINESS", "GUINESS"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "INSERT ... SELECT with CTE"
+			actor, cust := ACTOR(), CUSTOMER()
+			cte := NewCTE("active_customers", Select(cust.FIRST_NAME, cust.LAST_NAME).From(cust).Where(cust.ACTIVEBOOL))
+			q := With(cte).InsertInto(actor).
+				Columns(actor.FIRST_NAME, actor.LAST_NAME).
+				Select(Select(cte.Get("first_name"), cte.Get("last_name")).From(cte))
+			wantQuery := "INSERT INTO actor (first_name, last_name)" +
+				" WITH active_customers AS (SELECT customer.first_name, customer.last_name FROM customer WHERE customer.activebool)" +
+				" SELECT active_customers.first_name, active_customers.last_name FROM active_customers"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
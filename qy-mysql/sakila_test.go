@@ -0,0 +1,221 @@
+// The sakila tables used by the tests, adapted by hand from qx/sakila_test.go.
+
+package qy
+
+import "github.com/bokwoon95/qy/qx"
+
+type TABLE_ACTOR struct {
+	*qx.TableInfo
+	ACTOR_ID    qx.NumberField
+	FIRST_NAME  qx.StringField
+	LAST_NAME   qx.StringField
+	LAST_UPDATE qx.TimeField
+}
+
+func ACTOR() TABLE_ACTOR {
+	tbl := TABLE_ACTOR{TableInfo: qx.NewTableInfo("public", "actor")}
+	tbl.ACTOR_ID = qx.NewNumberField("actor_id", tbl.TableInfo)
+	tbl.FIRST_NAME = qx.NewStringField("first_name", tbl.TableInfo)
+	tbl.LAST_NAME = qx.NewStringField("last_name", tbl.TableInfo)
+	tbl.LAST_UPDATE = qx.NewTimeField("last_update", tbl.TableInfo)
+	return tbl
+}
+
+func (tbl TABLE_ACTOR) As(alias string) TABLE_ACTOR {
+	tbl2 := ACTOR()
+	tbl2.TableInfo.Alias = alias
+	return tbl2
+}
+
+type TABLE_ADDRESS struct {
+	*qx.TableInfo
+	ADDRESS     qx.StringField
+	ADDRESS2    qx.StringField
+	ADDRESS_ID  qx.NumberField
+	CITY_ID     qx.NumberField
+	DISTRICT    qx.StringField
+	LAST_UPDATE qx.TimeField
+	PHONE       qx.StringField
+	POSTAL_CODE qx.StringField
+}
+
+func ADDRESS() TABLE_ADDRESS {
+	tbl := TABLE_ADDRESS{TableInfo: qx.NewTableInfo("public", "address")}
+	tbl.ADDRESS = qx.NewStringField("address", tbl.TableInfo)
+	tbl.ADDRESS2 = qx.NewStringField("address2", tbl.TableInfo)
+	tbl.ADDRESS_ID = qx.NewNumberField("address_id", tbl.TableInfo)
+	tbl.CITY_ID = qx.NewNumberField("city_id", tbl.TableInfo)
+	tbl.DISTRICT = qx.NewStringField("district", tbl.TableInfo)
+	tbl.LAST_UPDATE = qx.NewTimeField("last_update", tbl.TableInfo)
+	tbl.PHONE = qx.NewStringField("phone", tbl.TableInfo)
+	tbl.POSTAL_CODE = qx.NewStringField("postal_code", tbl.TableInfo)
+	return tbl
+}
+
+func (tbl TABLE_ADDRESS) As(alias string) TABLE_ADDRESS {
+	tbl2 := ADDRESS()
+	tbl2.TableInfo.Alias = alias
+	return tbl2
+}
+
+type TABLE_CATEGORY struct {
+	*qx.TableInfo
+	CATEGORY_ID qx.NumberField
+	LAST_UPDATE qx.TimeField
+	NAME        qx.StringField
+}
+
+func CATEGORY() TABLE_CATEGORY {
+	tbl := TABLE_CATEGORY{TableInfo: qx.NewTableInfo("public", "category")}
+	tbl.CATEGORY_ID = qx.NewNumberField("category_id", tbl.TableInfo)
+	tbl.LAST_UPDATE = qx.NewTimeField("last_update", tbl.TableInfo)
+	tbl.NAME = qx.NewStringField("name", tbl.TableInfo)
+	return tbl
+}
+
+func (tbl TABLE_CATEGORY) As(alias string) TABLE_CATEGORY {
+	tbl2 := CATEGORY()
+	tbl2.TableInfo.Alias = alias
+	return tbl2
+}
+
+type TABLE_CITY struct {
+	*qx.TableInfo
+	CITY        qx.StringField
+	CITY_ID     qx.NumberField
+	COUNTRY_ID  qx.NumberField
+	LAST_UPDATE qx.TimeField
+}
+
+func CITY() TABLE_CITY {
+	tbl := TABLE_CITY{TableInfo: qx.NewTableInfo("public", "city")}
+	tbl.CITY = qx.NewStringField("city", tbl.TableInfo)
+	tbl.CITY_ID = qx.NewNumberField("city_id", tbl.TableInfo)
+	tbl.COUNTRY_ID = qx.NewNumberField("country_id", tbl.TableInfo)
+	tbl.LAST_UPDATE = qx.NewTimeField("last_update", tbl.TableInfo)
+	return tbl
+}
+
+func (tbl TABLE_CITY) As(alias string) TABLE_CITY {
+	tbl2 := CITY()
+	tbl2.TableInfo.Alias = alias
+	return tbl2
+}
+
+type TABLE_COUNTRY struct {
+	*qx.TableInfo
+	COUNTRY     qx.StringField
+	COUNTRY_ID  qx.NumberField
+	LAST_UPDATE qx.TimeField
+}
+
+func COUNTRY() TABLE_COUNTRY {
+	tbl := TABLE_COUNTRY{TableInfo: qx.NewTableInfo("public", "country")}
+	tbl.COUNTRY = qx.NewStringField("country", tbl.TableInfo)
+	tbl.COUNTRY_ID = qx.NewNumberField("country_id", tbl.TableInfo)
+	tbl.LAST_UPDATE = qx.NewTimeField("last_update", tbl.TableInfo)
+	return tbl
+}
+
+func (tbl TABLE_COUNTRY) As(alias string) TABLE_COUNTRY {
+	tbl2 := COUNTRY()
+	tbl2.TableInfo.Alias = alias
+	return tbl2
+}
+
+type TABLE_CUSTOMER struct {
+	*qx.TableInfo
+	ACTIVE      qx.NumberField
+	ACTIVEBOOL  qx.BooleanField
+	ADDRESS_ID  qx.NumberField
+	CREATE_DATE qx.TimeField
+	CUSTOMER_ID qx.NumberField
+	EMAIL       qx.StringField
+	FIRST_NAME  qx.StringField
+	LAST_NAME   qx.StringField
+	LAST_UPDATE qx.TimeField
+	STORE_ID    qx.NumberField
+}
+
+func CUSTOMER() TABLE_CUSTOMER {
+	tbl := TABLE_CUSTOMER{TableInfo: qx.NewTableInfo("public", "customer")}
+	tbl.ACTIVE = qx.NewNumberField("active", tbl.TableInfo)
+	tbl.ACTIVEBOOL = qx.NewBooleanField("activebool", tbl.TableInfo)
+	tbl.ADDRESS_ID = qx.NewNumberField("address_id", tbl.TableInfo)
+	tbl.CREATE_DATE = qx.NewTimeField("create_date", tbl.TableInfo)
+	tbl.CUSTOMER_ID = qx.NewNumberField("customer_id", tbl.TableInfo)
+	tbl.EMAIL = qx.NewStringField("email", tbl.TableInfo)
+	tbl.FIRST_NAME = qx.NewStringField("first_name", tbl.TableInfo)
+	tbl.LAST_NAME = qx.NewStringField("last_name", tbl.TableInfo)
+	tbl.LAST_UPDATE = qx.NewTimeField("last_update", tbl.TableInfo)
+	tbl.STORE_ID = qx.NewNumberField("store_id", tbl.TableInfo)
+	return tbl
+}
+
+func (tbl TABLE_CUSTOMER) As(alias string) TABLE_CUSTOMER {
+	tbl2 := CUSTOMER()
+	tbl2.TableInfo.Alias = alias
+	return tbl2
+}
+
+type TABLE_FILM struct {
+	*qx.TableInfo
+	DESCRIPTION          qx.StringField
+	FILM_ID              qx.NumberField
+	LANGUAGE_ID          qx.NumberField
+	LAST_UPDATE          qx.TimeField
+	LENGTH               qx.NumberField
+	ORIGINAL_LANGUAGE_ID qx.NumberField
+	RATING               qx.EnumField
+	RELEASE_YEAR         qx.NumberField
+	RENTAL_DURATION      qx.NumberField
+	RENTAL_RATE          qx.NumberField
+	REPLACEMENT_COST     qx.NumberField
+	SPECIAL_FEATURES     qx.ArrayField
+	TITLE                qx.StringField
+}
+
+func FILM() TABLE_FILM {
+	tbl := TABLE_FILM{TableInfo: qx.NewTableInfo("public", "film")}
+	tbl.DESCRIPTION = qx.NewStringField("description", tbl.TableInfo)
+	tbl.FILM_ID = qx.NewNumberField("film_id", tbl.TableInfo)
+	tbl.LANGUAGE_ID = qx.NewNumberField("language_id", tbl.TableInfo)
+	tbl.LAST_UPDATE = qx.NewTimeField("last_update", tbl.TableInfo)
+	tbl.LENGTH = qx.NewNumberField("length", tbl.TableInfo)
+	tbl.ORIGINAL_LANGUAGE_ID = qx.NewNumberField("original_language_id", tbl.TableInfo)
+	tbl.RATING = qx.NewEnumField("rating", tbl.TableInfo)
+	tbl.RELEASE_YEAR = qx.NewNumberField("release_year", tbl.TableInfo)
+	tbl.RENTAL_DURATION = qx.NewNumberField("rental_duration", tbl.TableInfo)
+	tbl.RENTAL_RATE = qx.NewNumberField("rental_rate", tbl.TableInfo)
+	tbl.REPLACEMENT_COST = qx.NewNumberField("replacement_cost", tbl.TableInfo)
+	tbl.SPECIAL_FEATURES = qx.NewArrayField("special_features", tbl.TableInfo)
+	tbl.TITLE = qx.NewStringField("title", tbl.TableInfo)
+	return tbl
+}
+
+func (tbl TABLE_FILM) As(alias string) TABLE_FILM {
+	tbl2 := FILM()
+	tbl2.TableInfo.Alias = alias
+	return tbl2
+}
+
+type TABLE_FILM_ACTOR struct {
+	*qx.TableInfo
+	ACTOR_ID    qx.NumberField
+	FILM_ID     qx.NumberField
+	LAST_UPDATE qx.TimeField
+}
+
+func FILM_ACTOR() TABLE_FILM_ACTOR {
+	tbl := TABLE_FILM_ACTOR{TableInfo: qx.NewTableInfo("public", "film_actor")}
+	tbl.ACTOR_ID = qx.NewNumberField("actor_id", tbl.TableInfo)
+	tbl.FILM_ID = qx.NewNumberField("film_id", tbl.TableInfo)
+	tbl.LAST_UPDATE = qx.NewTimeField("last_update", tbl.TableInfo)
+	return tbl
+}
+
+func (tbl TABLE_FILM_ACTOR) As(alias string) TABLE_FILM_ACTOR {
+	tbl2 := FILM_ACTOR()
+	tbl2.TableInfo.Alias = alias
+	return tbl2
+}
@@ -0,0 +1,144 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/bokwoon95/qy/qx"
+	"github.com/matryer/is"
+)
+
+func TestSelectQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           SelectQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "joins, where, group by, having, order by, limit and offset"
+			cust, addr, city := CUSTOMER().As("cust"), ADDRESS().As("addr"), CITY()
+			q := Select(city.CITY, Fieldf("COUNT(*)")).
+				From(cust).
+				Join(addr, addr.ADDRESS_ID.Eq(cust.ADDRESS_ID)).
+				LeftJoin(city, city.CITY_ID.Eq(addr.CITY_ID)).
+				Where(cust.ACTIVEBOOL, cust.FIRST_NAME.LikeString("A%")).
+				GroupBy(city.CITY).
+				Having(Predicatef("COUNT(*) > ?", 5)).
+				OrderBy(city.CITY.Desc()).
+				Limit(10).
+				Offset(20)
+			wantQuery := "SELECT city.city, COUNT(*)" +
+				" FROM customer AS cust" +
+				" JOIN address AS addr ON addr.address_id = cust.address_id" +
+				" LEFT JOIN city ON city.city_id = addr.city_id" +
+				" WHERE cust.activebool AND cust.first_name LIKE ?" +
+				" GROUP BY city.city" +
+				" HAVING COUNT(*) > ?" +
+				" ORDER BY city.city DESC" +
+				" LIMIT ? OFFSET ?"
+			wantArgs := []interface{}{"A%", 5, uint64(10), uint64(20)}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "SelectDistinct with CTE"
+			actor := ACTOR()
+			cte := NewCTE("actors", Select(actor.ACTOR_ID, actor.FIRST_NAME).From(actor))
+			q := SelectDistinct(cte.Get("first_name")).With(cte).From(cte)
+			wantQuery := "WITH actors AS (SELECT actor.actor_id, actor.first_name FROM actor)" +
+				" SELECT DISTINCT actors.first_name FROM actors"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "double quoted identifiers are rendered with backticks"
+			q := Select(Fieldf(`"order"."key"`)).From(qx.CustomTable{Format: `"order"`}).
+				Where(Predicatef(`"order"."name" = 'say "hello"'`))
+			wantQuery := "SELECT `order`.`key` FROM `order` WHERE `order`.`name` = 'say \"hello\"'"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+		func() TT {
+			DESCRIPTION := "subquery in FROM"
+			actor := ACTOR()
+			subq := Select(actor.ACTOR_ID).From(actor).Where(actor.FIRST_NAME.EqString("PENELOPE")).As("a")
+			q := Select(subq.Get("actor_id")).From(subq)
+			wantQuery := "SELECT a.actor_id FROM (SELECT actor.actor_id FROM actor WHERE actor.first_name = ?) AS a"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{"PENELOPE"}}
+		}(),
+		func() TT {
+			DESCRIPTION := "escaped question marks"
+			actor := ACTOR()
+			q := Select(actor.ACTOR_ID).From(actor).Where(Predicatef("? LIKE '%??%'", actor.FIRST_NAME))
+			wantQuery := "SELECT actor.actor_id FROM actor WHERE actor.first_name LIKE '%?%'"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestSelectQuery_ToSQLDialect(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		dialect     qx.Dialect
+		wantQuery   string
+	}
+	actor := ACTOR()
+	q := Select(actor.ACTOR_ID, Fieldf(`"order"`)).From(actor).
+		Where(actor.FIRST_NAME.EqString("PENELOPE"), actor.LAST_NAME.EqString("GUINESS"))
+	tests := []TT{
+		{
+			"MySQL",
+			qx.MySQL,
+			"SELECT actor.actor_id, `order` FROM actor WHERE actor.first_name = ? AND actor.last_name = ?",
+		},
+		{
+			"Postgres",
+			qx.Postgres,
+			`SELECT actor.actor_id, "order" FROM actor WHERE actor.first_name = $1 AND actor.last_name = $2`,
+		},
+		{
+			"SQLServer",
+			qx.SQLServer,
+			"SELECT actor.actor_id, [order] FROM actor WHERE actor.first_name = @p1 AND actor.last_name = @p2",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := q.ToSQLDialect(tt.dialect)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal([]interface{}{"PENELOPE", "GUINESS"}, gotArgs)
+		})
+	}
+}
+
+func TestSelectQuery_QuotedIdentifiers(t *testing.T) {
+	is := is.New(t)
+	user := &qx.TableInfo{Schema: "public", Name: "user"}
+	order := qx.NewNumberField("order", user)
+	displayName := qx.NewStringField("displayName", user)
+	gotQuery, gotArgs := Select(order, displayName).From(user).Where(order.GtInt(5)).ToSQL()
+	is.Equal("SELECT `user`.`order`, `user`.`displayName` FROM `user` WHERE `user`.`order` > ?", gotQuery)
+	is.Equal([]interface{}{5}, gotArgs)
+}
+
+func TestSelectQuery_RowLocking(t *testing.T) {
+	is := is.New(t)
+	cust := CUSTOMER().As("c")
+	q := Select(cust.CUSTOMER_ID).From(cust).Where(cust.STORE_ID.EqInt(1)).Limit(10).ForUpdate().Of(cust).SkipLocked()
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal("SELECT c.customer_id FROM customer AS c WHERE c.store_id = ? LIMIT ? FOR UPDATE OF c SKIP LOCKED", gotQuery)
+	is.Equal([]interface{}{1, uint64(10)}, gotArgs)
+	gotQuery, _ = Select(cust.CUSTOMER_ID).From(cust).ForShare().NoWait().ToSQL()
+	is.Equal("SELECT c.customer_id FROM customer AS c FOR SHARE NOWAIT", gotQuery)
+}
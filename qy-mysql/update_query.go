@@ -0,0 +1,287 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+type UpdateQuery struct {
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
+	// WITH
+	CTEs qx.CTEs
+	// UPDATE
+	UpdateTable qx.BaseTable
+	JoinGroups  qx.JoinTables
+	// SET
+	SetFields qx.FieldValueSets
+	// WHERE
+	WherePredicates qx.VariadicPredicate
+	// ORDER BY
+	OrderByFields qx.Fields
+	// LIMIT
+	LimitValue *uint64
+	// DB
+	DB qx.DB
+	// Logging
+	Log     qx.Logger
+	LogFlag int
+	LogSkip int
+}
+
+func (q UpdateQuery) ToSQL() (string, []interface{}) {
+	var buf = &strings.Builder{}
+	var args []interface{}
+	var excludeTableQualifiers []string
+	// WITH
+	q.CTEs.WriteSQL(buf, &args)
+	{ // UPDATE
+		updateQuery, updateArgs := "", []interface{}{}
+		if q.UpdateTable != nil {
+			updateQuery, updateArgs = q.UpdateTable.ToSQL()
+			// In a multiple-table UPDATE the SET fields may belong to any of
+			// the joined tables, so they must keep their table qualifiers
+			if len(q.JoinGroups) == 0 {
+				if q.UpdateTable.GetAlias() != "" {
+					excludeTableQualifiers = append(excludeTableQualifiers, q.UpdateTable.GetAlias())
+				} else if q.UpdateTable.GetName() != "" {
+					excludeTableQualifiers = append(excludeTableQualifiers, q.UpdateTable.GetName())
+				}
+			}
+		}
+		if updateQuery != "" {
+			if buf.Len() > 0 {
+				buf.WriteString(" ")
+			}
+			if q.UpdateTable.GetAlias() != "" {
+				buf.WriteString("UPDATE " + updateQuery + " AS " + qx.TableAlias(q.UpdateTable))
+			} else {
+				buf.WriteString("UPDATE " + updateQuery)
+			}
+			args = append(args, updateArgs...)
+		}
+	}
+	// JOIN
+	q.JoinGroups.WriteSQL(buf, &args)
+	// SET
+	q.SetFields.WriteSQL(buf, &args, "SET ", "", excludeTableQualifiers)
+	// WHERE
+	q.WherePredicates.Toplevel = true
+	q.WherePredicates.WriteSQL(buf, &args, "WHERE ", "", nil)
+	// ORDER BY
+	q.OrderByFields.WriteSQL(buf, &args, "ORDER BY ", "", nil)
+	// LIMIT
+	if q.LimitValue != nil {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("LIMIT ?")
+		args = append(args, *q.LimitValue)
+	}
+	query := buf.String()
+	if !q.Nested {
+		if q.Dialect == nil {
+			q.Dialect = qx.MySQL
+		}
+		query = qx.Rebind(q.Dialect, query)
+		if q.Log != nil {
+			var logOutput string
+			switch {
+			case LStats&q.LogFlag != 0:
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
+			case LInterpolate&q.LogFlag != 0:
+				logOutput = "Executing query: " + q.Dialect.InterpolateSQL(query, args...)
+			default:
+				logOutput = "Executing query: " + query + " " + fmt.Sprint(args)
+			}
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logOutput)
+			default:
+				q.Log.Output(q.LogSkip+1, logOutput)
+			}
+		}
+	}
+	return query, args
+}
+
+// ToSQLDialect marshals the UpdateQuery into an SQL query for the given dialect.
+func (q UpdateQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
+func (q UpdateQuery) GetAlias() string {
+	return q.Alias
+}
+
+func (q UpdateQuery) GetName() string {
+	return ""
+}
+
+func (q UpdateQuery) NestThis() qx.Query {
+	q.Nested = true
+	return q
+}
+
+func (q UpdateQuery) As(alias string) UpdateQuery {
+	q.Alias = alias
+	return q
+}
+
+func Update(table qx.BaseTable) UpdateQuery {
+	return UpdateQuery{
+		UpdateTable: table,
+		Alias:       qx.RandomString(8),
+	}
+}
+
+func (q UpdateQuery) With(cteList ...qx.CTE) UpdateQuery {
+	q.CTEs = append(q.CTEs, cteList...)
+	return q
+}
+
+func (q UpdateQuery) Update(tbl qx.BaseTable) UpdateQuery {
+	q.UpdateTable = tbl
+	return q
+}
+
+func (q UpdateQuery) Join(tbl qx.Table, pred qx.Predicate, preds ...qx.Predicate) UpdateQuery {
+	preds = append([]qx.Predicate{pred}, preds...)
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeDefault,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) LeftJoin(tbl qx.Table, pred qx.Predicate, preds ...qx.Predicate) UpdateQuery {
+	preds = append([]qx.Predicate{pred}, preds...)
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeLeft,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) RightJoin(tbl qx.Table, pred qx.Predicate, preds ...qx.Predicate) UpdateQuery {
+	preds = append([]qx.Predicate{pred}, preds...)
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeRight,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) CrossJoin(tbl qx.Table) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType: qx.JoinTypeCross,
+		Table:    tbl,
+	})
+	return q
+}
+
+func (q UpdateQuery) JoinLateral(tbl qx.Table, preds ...qx.Predicate) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeDefault,
+		Lateral:      true,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) LeftJoinLateral(tbl qx.Table, preds ...qx.Predicate) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeLeft,
+		Lateral:      true,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) JoinUsing(tbl qx.Table, fields ...qx.Field) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:    qx.JoinTypeDefault,
+		Table:       tbl,
+		UsingFields: fields,
+	})
+	return q
+}
+
+func (q UpdateQuery) NaturalJoin(tbl qx.Table) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType: qx.JoinTypeNatural,
+		Table:    tbl,
+	})
+	return q
+}
+
+func (q UpdateQuery) Set(sets ...qx.FieldValueSet) UpdateQuery {
+	q.SetFields = append(q.SetFields, sets...)
+	return q
+}
+
+func (q UpdateQuery) Where(preds ...qx.Predicate) UpdateQuery {
+	q.WherePredicates.Predicates = append(q.WherePredicates.Predicates, preds...)
+	return q
+}
+
+func (q UpdateQuery) OrderBy(fields ...qx.Field) UpdateQuery {
+	q.OrderByFields = append(q.OrderByFields, fields...)
+	return q
+}
+
+func (q UpdateQuery) Limit(limit int) UpdateQuery {
+	if limit < 0 {
+		limit = -limit
+	}
+	num := uint64(limit)
+	q.LimitValue = &num
+	return q
+}
+
+func (q UpdateQuery) Exec(db qx.DB) (sql.Result, error) {
+	q.LogSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+func (q UpdateQuery) ExecContext(ctx context.Context, db qx.DB) (res sql.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	if db == nil {
+		if q.DB == nil {
+			return res, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		res, err = db.Exec(query, args...)
+	} else {
+		res, err = db.ExecContext(ctx, query, args...)
+	}
+	return res, err
+}
@@ -0,0 +1,57 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestUpdateQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           UpdateQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "single-table update with order by and limit"
+			actor := ACTOR()
+			q := Update(actor).
+				Set(actor.FIRST_NAME.Set("PENELOPE")).
+				Where(actor.LAST_NAME.EqString("GUINESS")).
+				OrderBy(actor.ACTOR_ID).
+				Limit(1)
+			wantQuery := "UPDATE actor SET first_name = ? WHERE actor.last_name = ? ORDER BY actor.actor_id LIMIT ?"
+			wantArgs := []interface{}{"PENELOPE", "GUINESS", uint64(1)}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "multiple-table update keeps the SET table qualifiers"
+			cust, addr := CUSTOMER().As("cust"), ADDRESS().As("addr")
+			q := Update(cust).
+				Join(addr, addr.ADDRESS_ID.Eq(cust.ADDRESS_ID)).
+				Set(
+					cust.ACTIVEBOOL.Set(false),
+					addr.PHONE.Set(""),
+				).
+				Where(addr.DISTRICT.EqString("Alberta"))
+			wantQuery := "UPDATE customer AS cust" +
+				" JOIN address AS addr ON addr.address_id = cust.address_id" +
+				" SET cust.activebool = ?, addr.phone = ?" +
+				" WHERE addr.district = ?"
+			wantArgs := []interface{}{false, "", "Alberta"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
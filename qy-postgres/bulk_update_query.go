@@ -0,0 +1,260 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+// MaxBindParams is the maximum number of bind parameters postgres accepts in
+// a single statement.
+const MaxBindParams = 65535
+
+// BulkUpdateQuery represents an UPDATE of many rows with different values in
+// a single statement i.e.
+//
+//	UPDATE table SET col = v.col ...
+//	FROM (VALUES (...), (...)) AS v (key, col ...)
+//	WHERE table.key = v.key
+//
+// Each row holds the values of the KeyFields followed by the values of the
+// SetFields. When executed the rows are split into as many statements as
+// needed to stay under MaxParams bind parameters.
+type BulkUpdateQuery struct {
+	Dialect qx.Dialect
+	// UPDATE
+	UpdateTable qx.BaseTable
+	// WHERE key = v.key
+	KeyFields []qx.Field
+	// SET col = v.col
+	SetFields []qx.Field
+	// VALUES
+	Rows qx.ValuesList
+	// ColumnTypes overrides the SQL type that the values of a column are cast
+	// into, keyed by the column name. By default the type is inferred from the
+	// Go type of the values, except for strings which are left uncast (and so
+	// are treated as text).
+	ColumnTypes map[string]string
+	// MaxParams is the maximum number of bind parameters per statement. It
+	// defaults to MaxBindParams.
+	MaxParams int
+	// DB
+	DB qx.DB
+	// Logging
+	Log     qx.Logger
+	LogFlag int
+	LogSkip int
+}
+
+// UpdateFromValues returns a new BulkUpdateQuery that updates the table rows
+// matching the keyFields. Each row holds the values of the keyFields followed
+// by the values of the fields passed to Set.
+func UpdateFromValues(table qx.BaseTable, keyFields []qx.Field, rows [][]interface{}) BulkUpdateQuery {
+	return BulkUpdateQuery{
+		UpdateTable: table,
+		KeyFields:   keyFields,
+		Rows:        rows,
+	}
+}
+
+// Set adds the fields to be updated, in the same order as their values in
+// each row.
+func (q BulkUpdateQuery) Set(fields ...qx.Field) BulkUpdateQuery {
+	q.SetFields = append(q.SetFields, fields...)
+	return q
+}
+
+// CastAs overrides the SQL type that the values of the field are cast into
+// e.g. CastAs(film.RATING, "mpaa_rating") for an enum column. Go strings are
+// not cast by default, so CastAs is needed for every column that is set or
+// matched with strings but is not text, such as uuid, enum, json or inet
+// columns.
+func (q BulkUpdateQuery) CastAs(field qx.Field, typ string) BulkUpdateQuery {
+	columnTypes := make(map[string]string, len(q.ColumnTypes)+1)
+	for name, t := range q.ColumnTypes {
+		columnTypes[name] = t
+	}
+	columnTypes[field.GetName()] = typ
+	q.ColumnTypes = columnTypes
+	return q
+}
+
+// ToSQL marshals the BulkUpdateQuery into a single SQL query and args,
+// regardless of MaxParams.
+func (q BulkUpdateQuery) ToSQL() (string, []interface{}) {
+	q.LogSkip += 1
+	return q.updateQuery(q.Rows).ToSQL()
+}
+
+// updateQuery returns the UpdateQuery that updates the rows.
+func (q BulkUpdateQuery) updateQuery(rows qx.ValuesList) UpdateQuery {
+	fields := append(append([]qx.Field{}, q.KeyFields...), q.SetFields...)
+	columns := make([]string, len(fields))
+	for i := range fields {
+		columns[i] = fields[i].GetName()
+	}
+	v := qx.Values(q.castRows(rows, columns)...).As("v", columns...)
+	uq := Update(q.UpdateTable).From(v)
+	// v.Number is only used as a column reference, the actual type of the
+	// column does not matter.
+	for _, field := range q.SetFields {
+		uq = uq.Set(qx.FieldValueSet{Field: field, Value: v.Number(field.GetName())})
+	}
+	for _, field := range q.KeyFields {
+		uq = uq.Where(qx.BinaryPredicate{
+			Operator:   qx.PredicateEq,
+			LeftField:  field,
+			RightField: v.Number(field.GetName()),
+		})
+	}
+	uq.Dialect = q.Dialect
+	uq.Log = q.Log
+	uq.LogFlag = q.LogFlag
+	uq.LogSkip = q.LogSkip
+	return uq
+}
+
+// castRows returns the rows with the values of the first row cast into the
+// type of their column, since postgres infers the types of a VALUES list from
+// its first row and would otherwise treat every bind parameter as text.
+func (q BulkUpdateQuery) castRows(rows qx.ValuesList, columns []string) qx.ValuesList {
+	if len(rows) == 0 {
+		return rows
+	}
+	first := make([]interface{}, len(rows[0]))
+	copy(first, rows[0])
+	for i := range first {
+		if i >= len(columns) {
+			break
+		}
+		if _, ok := first[i].(qx.Field); ok {
+			continue
+		}
+		typ, ok := q.ColumnTypes[columns[i]]
+		if !ok {
+			typ = inferColumnType(rows, i)
+		}
+		if typ == "" {
+			continue
+		}
+		first[i] = qx.CustomField{
+			Format: "CAST(? AS " + typ + ")",
+			Values: []interface{}{first[i]},
+		}
+	}
+	casted := make(qx.ValuesList, len(rows))
+	copy(casted, rows)
+	casted[0] = first
+	return casted
+}
+
+// inferColumnType returns the SQL type of the first non-NULL value in the
+// column of the rows, or an empty string if it cannot be inferred. Strings
+// are not inferred because they are also used for the values of uuid, enum,
+// json and similar columns.
+func inferColumnType(rows qx.ValuesList, column int) string {
+	for i := range rows {
+		if column >= len(rows[i]) {
+			continue
+		}
+		switch rows[i][column].(type) {
+		case nil:
+			continue
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return "BIGINT"
+		case float32, float64:
+			return "DOUBLE PRECISION"
+		case bool:
+			return "BOOLEAN"
+		case time.Time:
+			return "TIMESTAMPTZ"
+		case []byte:
+			return "BYTEA"
+		}
+		return ""
+	}
+	return ""
+}
+
+// chunks splits the rows so that every chunk stays under MaxParams bind
+// parameters. The parameters of each row are counted by rendering it, since a
+// value may be a Field or expression that carries more than one argument.
+func (q BulkUpdateQuery) chunks() []qx.ValuesList {
+	if len(q.Rows) == 0 {
+		return nil
+	}
+	maxParams := q.MaxParams
+	if maxParams <= 0 {
+		maxParams = MaxBindParams
+	}
+	q.Log = nil
+	_, fixedArgs := q.updateQuery(nil).ToSQL()
+	maxParams -= len(fixedArgs)
+	var chunks []qx.ValuesList
+	start, params := 0, 0
+	for i := range q.Rows {
+		var rowArgs []interface{}
+		qx.ValuesList{q.Rows[i]}.WriteSQL(&strings.Builder{}, &rowArgs, "", "")
+		if i > start && params+len(rowArgs) > maxParams {
+			chunks = append(chunks, q.Rows[start:i])
+			start, params = i, 0
+		}
+		params += len(rowArgs)
+	}
+	return append(chunks, q.Rows[start:])
+}
+
+func (q BulkUpdateQuery) Exec(db qx.DB) (sql.Result, error) {
+	q.LogSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+// ExecContext executes one UPDATE statement per chunk of rows and returns the
+// total number of rows affected. The statements are not atomic unless db is a
+// transaction.
+func (q BulkUpdateQuery) ExecContext(ctx context.Context, db qx.DB) (sql.Result, error) {
+	var res bulkResult
+	if db == nil {
+		if q.DB == nil {
+			return res, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	if len(q.KeyFields) == 0 {
+		return res, errors.New("UpdateFromValues needs at least one key field")
+	}
+	if len(q.SetFields) == 0 {
+		return res, errors.New("UpdateFromValues needs at least one field to Set")
+	}
+	q.LogSkip += 1
+	for _, rows := range q.chunks() {
+		chunkRes, err := q.updateQuery(rows).ExecContext(ctx, db)
+		if err != nil {
+			return res, err
+		}
+		rowsAffected, err := chunkRes.RowsAffected()
+		if err != nil {
+			return res, err
+		}
+		res.rowsAffected += rowsAffected
+	}
+	return res, nil
+}
+
+// bulkResult is the aggregated sql.Result of every statement executed by a
+// BulkUpdateQuery or a batched InsertQuery.
+type bulkResult struct {
+	rowsAffected int64
+}
+
+func (r bulkResult) LastInsertId() (int64, error) {
+	return 0, errors.New("LastInsertId is not supported by postgres")
+}
+
+func (r bulkResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
@@ -0,0 +1,107 @@
+package qy
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bokwoon95/qy/qx"
+	"github.com/bokwoon95/qy/tables-postgres"
+	"github.com/matryer/is"
+)
+
+func TestBulkUpdateQuery_ToSQL(t *testing.T) {
+	is := is.New(t)
+	f := tables.FILM()
+	q := UpdateFromValues(f, []Field{f.FILM_ID}, [][]interface{}{
+		{1, "Academy Dinosaur", nil},
+		{2, "Ace Goldfinger", 4.99},
+	}).Set(f.TITLE, f.RENTAL_RATE)
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal("UPDATE film SET title = v.title, rental_rate = v.rental_rate"+
+		" FROM (VALUES (CAST($1 AS BIGINT), $2, CAST(NULL AS DOUBLE PRECISION)), ($3, $4, $5))"+
+		" AS v (film_id, title, rental_rate)"+
+		" WHERE film.film_id = v.film_id", gotQuery)
+	is.Equal([]interface{}{1, "Academy Dinosaur", 2, "Ace Goldfinger", 4.99}, gotArgs)
+
+	// CastAs overrides the inferred type
+	gotQuery, _ = UpdateFromValues(f, []Field{f.FILM_ID}, [][]interface{}{{1, "PG"}}).
+		Set(f.RATING).
+		CastAs(f.RATING, "mpaa_rating").
+		ToSQL()
+	is.Equal("UPDATE film SET rating = v.rating"+
+		" FROM (VALUES (CAST($1 AS BIGINT), CAST($2 AS mpaa_rating))) AS v (film_id, rating)"+
+		" WHERE film.film_id = v.film_id", gotQuery)
+
+	// strings are left uncast, so non-text columns such as a uuid key need
+	// CastAs
+	sessions := qx.NewTableInfo("public", "sessions")
+	sessionID := qx.NewStringField("session_id", sessions)
+	expiresAt := qx.NewTimeField("expires_at", sessions)
+	q = UpdateFromValues(sessions, []Field{sessionID}, [][]interface{}{
+		{"0b6a1d1e-7c51-4a8e-9d0e-1f0f6b4c2d3a", nil},
+	}).Set(expiresAt)
+	gotQuery, _ = q.ToSQL()
+	is.Equal("UPDATE sessions SET expires_at = v.expires_at"+
+		" FROM (VALUES ($1, $2)) AS v (session_id, expires_at)"+
+		" WHERE sessions.session_id = v.session_id", gotQuery)
+	gotQuery, gotArgs = q.CastAs(sessionID, "UUID").CastAs(expiresAt, "TIMESTAMPTZ").ToSQL()
+	is.Equal("UPDATE sessions SET expires_at = v.expires_at"+
+		" FROM (VALUES (CAST($1 AS UUID), CAST(NULL AS TIMESTAMPTZ))) AS v (session_id, expires_at)"+
+		" WHERE sessions.session_id = v.session_id", gotQuery)
+	is.Equal([]interface{}{"0b6a1d1e-7c51-4a8e-9d0e-1f0f6b4c2d3a"}, gotArgs)
+}
+
+func TestBulkUpdateQuery_Chunks(t *testing.T) {
+	is := is.New(t)
+	f := tables.FILM()
+	var rows [][]interface{}
+	for i := 1; i <= 5; i++ {
+		rows = append(rows, []interface{}{i, "title"})
+	}
+	q := UpdateFromValues(f, []Field{f.FILM_ID}, rows).Set(f.TITLE)
+	is.Equal(1, len(q.chunks()))
+	q.MaxParams = 4 // 2 rows of 2 columns
+	chunks := q.chunks()
+	is.Equal(3, len(chunks))
+	is.Equal(2, len(chunks[0]))
+	is.Equal(2, len(chunks[1]))
+	is.Equal(1, len(chunks[2]))
+	// every chunk casts its own first row
+	gotQuery, gotArgs := q.updateQuery(chunks[2]).ToSQL()
+	is.Equal("UPDATE film SET title = v.title"+
+		" FROM (VALUES (CAST($1 AS BIGINT), $2)) AS v (film_id, title)"+
+		" WHERE film.film_id = v.film_id", gotQuery)
+	is.Equal([]interface{}{5, "title"}, gotArgs)
+}
+
+func TestBulkUpdateQuery_ChunksFieldArgs(t *testing.T) {
+	is := is.New(t)
+	f := tables.FILM()
+	var rows [][]interface{}
+	for i := 1; i <= 5; i++ {
+		title := qx.CustomField{Format: "? || ?", Values: []interface{}{"title ", i}}
+		rows = append(rows, []interface{}{i, title})
+	}
+	q := UpdateFromValues(f, []Field{f.FILM_ID}, rows).Set(f.TITLE)
+	q.MaxParams = 6 // 2 rows of 3 args each, not 3 rows of 2 columns
+	chunks := q.chunks()
+	is.Equal(3, len(chunks))
+	is.Equal(2, len(chunks[0]))
+	is.Equal(2, len(chunks[1]))
+	is.Equal(1, len(chunks[2]))
+	for _, chunk := range chunks {
+		_, args := q.updateQuery(chunk).ToSQL()
+		is.True(len(args) <= q.MaxParams)
+	}
+}
+
+func TestBulkUpdateQuery_Rejected(t *testing.T) {
+	is := is.New(t)
+	f := tables.FILM()
+	db := &sql.DB{}
+	rows := [][]interface{}{{1, "title"}}
+	_, err := UpdateFromValues(f, nil, rows).Set(f.TITLE).Exec(db)
+	is.True(err != nil) // no key fields
+	_, err = UpdateFromValues(f, []Field{f.FILM_ID}, rows).Exec(db)
+	is.True(err != nil) // no fields to Set
+}
@@ -0,0 +1,103 @@
+package qy
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/bokwoon95/qy/tables-postgres"
+	"github.com/matryer/is"
+)
+
+func TestCompoundQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           CompoundQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	cust := tables.CUSTOMER()
+	store := func(id int) SelectQuery {
+		return Select(cust.CUSTOMER_ID, cust.FIRST_NAME).From(cust).Where(cust.STORE_ID.EqInt(id))
+	}
+	tests := []TT{
+		{
+			"UNION with outer ORDER BY, LIMIT and OFFSET",
+			store(1).Union(store(2)).OrderBy(cust.FIRST_NAME).Limit(10).Offset(20),
+			"SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $1" +
+				" UNION SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $2" +
+				" ORDER BY first_name LIMIT $3 OFFSET $4",
+			[]interface{}{1, 2, uint64(10), uint64(20)},
+		},
+		{
+			"branches with their own LIMIT are parenthesized",
+			store(1).OrderBy(cust.CUSTOMER_ID).Limit(3).UnionAll(store(2).Limit(3)),
+			"(SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $1 ORDER BY customer.customer_id LIMIT $2)" +
+				" UNION ALL (SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $3 LIMIT $4)",
+			[]interface{}{1, uint64(3), 2, uint64(3)},
+		},
+		{
+			"same operator adds branches",
+			store(1).Union(store(2)).Union(store(3)),
+			"SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $1" +
+				" UNION SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $2" +
+				" UNION SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $3",
+			[]interface{}{1, 2, 3},
+		},
+		{
+			"different operator nests the compound query",
+			store(1).Union(store(2)).Except(store(3)),
+			"(SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $1" +
+				" UNION SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $2)" +
+				" EXCEPT SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $3",
+			[]interface{}{1, 2, 3},
+		},
+		{
+			"INTERSECT",
+			store(1).Intersect(store(2)),
+			"SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $1" +
+				" INTERSECT SELECT customer.customer_id, customer.first_name FROM customer WHERE customer.store_id = $2",
+			[]interface{}{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestCompoundQuery_Subquery(t *testing.T) {
+	is := is.New(t)
+	cust := tables.CUSTOMER()
+	u := Select(cust.CUSTOMER_ID).From(cust).Where(cust.STORE_ID.EqInt(1)).
+		Union(Select(cust.CUSTOMER_ID).From(cust).Where(cust.ACTIVE.EqInt(0))).
+		As("u")
+	gotQuery, gotArgs := Select(u.Get("customer_id")).From(u).ToSQL()
+	is.Equal("SELECT u.customer_id FROM (SELECT customer.customer_id FROM customer WHERE customer.store_id = $1"+
+		" UNION SELECT customer.customer_id FROM customer WHERE customer.active = $2) AS u", gotQuery)
+	is.Equal([]interface{}{1, 0}, gotArgs)
+}
+
+func TestCompoundQuery_Rejected(t *testing.T) {
+	is := is.New(t)
+	actor, cust := tables.ACTOR(), tables.CUSTOMER()
+	db := &sql.DB{} // never reached, the query is rejected before it is executed
+	var firstName string
+	err := Select().From(actor).Union(Select().From(cust)).SelectRowx(func(row Row) {
+		firstName = row.String(actor.FIRST_NAME)
+	}).Fetch(db)
+	is.True(err != nil)
+	is.Equal("compound query branch 2 does not select any fields", err.Error())
+	_, err = Select(cust.CUSTOMER_ID).From(cust).ForUpdate().Union(Select(cust.CUSTOMER_ID).From(cust)).Exec(db)
+	is.True(err != nil)
+	is.Equal("row locking clauses are not allowed in UNION, INTERSECT or EXCEPT", err.Error())
+	_, err = Select(cust.CUSTOMER_ID).From(cust).Union(Select(cust.CUSTOMER_ID).From(cust).ForShare()).Except(Select(cust.CUSTOMER_ID).From(cust)).Exec(db)
+	is.True(err != nil)
+	is.Equal("row locking clauses are not allowed in UNION, INTERSECT or EXCEPT", err.Error())
+	is.Equal("", firstName)
+}
@@ -15,8 +15,9 @@ import (
 )
 
 type DeleteQuery struct {
-	Nested bool
-	Alias  string
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
 	// WITH
 	CTEs qx.CTEs
 	// DELETE FROM
@@ -59,7 +60,7 @@ func (q DeleteQuery) ToSQL() (string, []interface{}) {
 				buf.WriteString(" ")
 			}
 			if q.FromTable.GetAlias() != "" {
-				buf.WriteString("DELETE FROM " + deleteQuery + " AS " + q.FromTable.GetAlias())
+				buf.WriteString("DELETE FROM " + deleteQuery + " AS " + qx.TableAlias(q.FromTable))
 			} else {
 				buf.WriteString("DELETE FROM " + deleteQuery)
 			}
@@ -76,7 +77,7 @@ func (q DeleteQuery) ToSQL() (string, []interface{}) {
 				buf.WriteString(" ")
 			}
 			if q.UsingTable.GetAlias() != "" {
-				buf.WriteString("USING " + usingQuery + " AS " + q.UsingTable.GetAlias())
+				buf.WriteString("USING " + usingQuery + " AS " + qx.TableAlias(q.UsingTable))
 			} else {
 				buf.WriteString("USING " + usingQuery)
 			}
@@ -92,15 +93,18 @@ func (q DeleteQuery) ToSQL() (string, []interface{}) {
 	q.ReturningFields.WriteSQLWithAlias(buf, &args, "RETURNING ", "", nil)
 	query := buf.String()
 	if !q.Nested {
-		query = qx.MySQLToPostgresPlaceholders(query)
+		if q.Dialect == nil {
+			q.Dialect = qx.Postgres
+		}
+		query = qx.Rebind(q.Dialect, query)
 		if q.Log != nil {
 			var logOutput string
 			switch {
 			case LStats&q.LogFlag != 0:
 				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
-					"\n----[ with bind values ]----\n" + qx.PostgresInterpolateSQL(query, args...)
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
 			case LInterpolate&q.LogFlag != 0:
-				logOutput = "Executing query: " + qx.PostgresInterpolateSQL(query, args...)
+				logOutput = "Executing query: " + q.Dialect.InterpolateSQL(query, args...)
 			default:
 				logOutput = "Executing query: " + query + " " + fmt.Sprint(args)
 			}
@@ -115,6 +119,13 @@ func (q DeleteQuery) ToSQL() (string, []interface{}) {
 	return query, args
 }
 
+// ToSQLDialect marshals the DeleteQuery into an SQL query for the given dialect.
+func (q DeleteQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
 func (q DeleteQuery) GetAlias() string {
 	return q.Alias
 }
@@ -203,6 +214,43 @@ func (q DeleteQuery) CrossJoin(tbl qx.Table) DeleteQuery {
 	return q
 }
 
+func (q DeleteQuery) JoinLateral(tbl qx.Table, predicates ...qx.Predicate) DeleteQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeDefault,
+		Lateral:      true,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: predicates},
+	})
+	return q
+}
+
+func (q DeleteQuery) LeftJoinLateral(tbl qx.Table, predicates ...qx.Predicate) DeleteQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeLeft,
+		Lateral:      true,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: predicates},
+	})
+	return q
+}
+
+func (q DeleteQuery) JoinUsing(tbl qx.Table, fields ...qx.Field) DeleteQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:    qx.JoinTypeDefault,
+		Table:       tbl,
+		UsingFields: fields,
+	})
+	return q
+}
+
+func (q DeleteQuery) NaturalJoin(tbl qx.Table) DeleteQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType: qx.JoinTypeNatural,
+		Table:    tbl,
+	})
+	return q
+}
+
 func (q DeleteQuery) Where(predicates ...qx.Predicate) DeleteQuery {
 	q.WherePredicates.Predicates = append(q.WherePredicates.Predicates, predicates...)
 	return q
@@ -334,9 +382,17 @@ func (q DeleteQuery) Exec(db qx.DB) (sql.Result, error) {
 	return q.ExecContext(nil, db)
 }
 
-func (q DeleteQuery) ExecContext(ctx context.Context, db qx.DB) (sql.Result, error) {
-	var res sql.Result
-	var err error
+func (q DeleteQuery) ExecContext(ctx context.Context, db qx.DB) (res sql.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
 	if db == nil {
 		if q.DB == nil {
 			return res, errors.New("DB cannot be nil")
@@ -9,26 +9,31 @@ import (
 func Exists(query qx.Query, db qx.DB) (exists bool, err error) {
 	var dbV2 qx.DB
 	var logger qx.Logger
+	var dialect qx.Dialect
 	switch q := query.(type) {
 	case SelectQuery:
 		q.SelectFields = []qx.Field{Fieldf("1")}
 		dbV2 = q.DB
 		logger = q.Log
+		dialect = q.Dialect
 		query = q
 	case InsertQuery:
 		q.ReturningFields = []qx.Field{Fieldf("1")}
 		dbV2 = q.DB
 		logger = q.Log
+		dialect = q.Dialect
 		query = q
 	case UpdateQuery:
 		q.ReturningFields = []qx.Field{Fieldf("1")}
 		dbV2 = q.DB
 		logger = q.Log
+		dialect = q.Dialect
 		query = q
 	case DeleteQuery:
 		q.ReturningFields = []qx.Field{Fieldf("1")}
 		dbV2 = q.DB
 		logger = q.Log
+		dialect = q.Dialect
 		query = q
 	default:
 		return exists, errors.New("query is not a SelectQuery, InsertQuery, UpdateQuery or DeleteQuery")
@@ -39,11 +44,14 @@ func Exists(query qx.Query, db qx.DB) (exists bool, err error) {
 	if db == nil {
 		return exists, errors.New("DB is not set")
 	}
+	if dialect == nil {
+		dialect = qx.Postgres
+	}
 	queryString, args := query.ToSQL()
 	queryString = "SELECT EXISTS(" + queryString + ")"
 	rows, err := db.Query(queryString, args...)
 	if logger != nil {
-		interpolatedQuery := qx.PostgresInterpolateSQL(queryString, args...)
+		interpolatedQuery := dialect.InterpolateSQL(queryString, args...)
 		logger.Output(1, interpolatedQuery)
 	}
 	if err != nil {
@@ -15,8 +15,9 @@ import (
 )
 
 type InsertQuery struct {
-	Nested bool
-	Alias  string
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
 	// WITH
 	CTEs qx.CTEs
 	// INSERT INTO
@@ -66,7 +67,7 @@ func (q InsertQuery) ToSQL() (string, []interface{}) {
 				buf.WriteString(" ")
 			}
 			if q.IntoTable.GetAlias() != "" {
-				buf.WriteString("INSERT INTO " + intoQuery + " AS " + q.IntoTable.GetAlias())
+				buf.WriteString("INSERT INTO " + intoQuery + " AS " + qx.TableAlias(q.IntoTable))
 			} else {
 				buf.WriteString("INSERT INTO " + intoQuery)
 			}
@@ -127,15 +128,18 @@ func (q InsertQuery) ToSQL() (string, []interface{}) {
 	q.ReturningFields.WriteSQLWithAlias(buf, &args, "RETURNING ", "", nil)
 	query := buf.String()
 	if !q.Nested {
-		query = qx.MySQLToPostgresPlaceholders(query)
+		if q.Dialect == nil {
+			q.Dialect = qx.Postgres
+		}
+		query = qx.Rebind(q.Dialect, query)
 		if q.Log != nil {
 			var logOutput string
 			switch {
 			case LStats&q.LogFlag != 0:
 				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
-					"\n----[ with bind values ]----\n" + qx.PostgresInterpolateSQL(query, args...)
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
 			case LInterpolate&q.LogFlag != 0:
-				logOutput = "Executing query: " + qx.PostgresInterpolateSQL(query, args...)
+				logOutput = "Executing query: " + q.Dialect.InterpolateSQL(query, args...)
 			default:
 				logOutput = "Executing query: " + query + " " + fmt.Sprint(args)
 			}
@@ -150,6 +154,13 @@ func (q InsertQuery) ToSQL() (string, []interface{}) {
 	return query, args
 }
 
+// ToSQLDialect marshals the InsertQuery into an SQL query for the given dialect.
+func (q InsertQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
 func InsertInto(table qx.BaseTable) InsertQuery {
 	return InsertQuery{
 		IntoTable: table,
@@ -230,7 +241,7 @@ func (c insertConflict) DoUpdateSet(sets ...qx.FieldValueSet) InsertQuery {
 }
 
 func Excluded(field qx.Field) qx.CustomField {
-	return qx.CustomField{Format: "EXCLUDED." + field.GetName()}
+	return qx.CustomField{Format: "EXCLUDED." + qx.QuoteIdentifier(field.GetName())}
 }
 
 func (q InsertQuery) Where(predicates ...qx.Predicate) InsertQuery {
@@ -364,9 +375,17 @@ func (q InsertQuery) Exec(db qx.DB) (sql.Result, error) {
 	return q.ExecContext(nil, db)
 }
 
-func (q InsertQuery) ExecContext(ctx context.Context, db qx.DB) (sql.Result, error) {
-	var res sql.Result
-	var err error
+func (q InsertQuery) ExecContext(ctx context.Context, db qx.DB) (res sql.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
 	if db == nil {
 		if q.DB == nil {
 			return res, errors.New("DB cannot be nil")
@@ -383,6 +402,80 @@ func (q InsertQuery) ExecContext(ctx context.Context, db qx.DB) (sql.Result, err
 	return res, err
 }
 
+// ExecBatched executes the InsertQuery as one INSERT statement per batch of at
+// most batchSize rows and returns the total number of rows affected. The batch
+// size is lowered if needed so that every statement stays under MaxBindParams
+// bind parameters; a batchSize of 0 means as many rows as will fit. Pass a
+// transaction as db to make the batches atomic.
+func (q InsertQuery) ExecBatched(ctx context.Context, db qx.DB, batchSize int) (sql.Result, error) {
+	var res bulkResult
+	q.LogSkip += 1
+	for _, rows := range q.batches(batchSize) {
+		q.ValuesList = rows
+		batchRes, err := q.ExecContext(ctx, db)
+		if err != nil {
+			return res, err
+		}
+		rowsAffected, err := batchRes.RowsAffected()
+		if err != nil {
+			return res, err
+		}
+		res.rowsAffected += rowsAffected
+	}
+	return res, nil
+}
+
+// FetchBatched is like ExecBatched, but fetches the RETURNING rows of every
+// batch. The mapper and accumulator are called for every returned row across
+// all batches, in order. Pass a transaction as db to make the batches atomic.
+func (q InsertQuery) FetchBatched(ctx context.Context, db qx.DB, batchSize int) error {
+	if q.Mapper != nil && q.Accumulator == nil {
+		return errors.New("FetchBatched needs an accumulator, use Fetch to fetch a single row")
+	}
+	q.LogSkip += 1
+	for _, rows := range q.batches(batchSize) {
+		q.ValuesList = rows
+		err := q.FetchContext(ctx, db)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// batches splits the ValuesList into batches of at most batchSize rows, such
+// that each batch stays under MaxBindParams bind parameters. The bind
+// parameters taken up by the rest of the query (CTEs, ON CONFLICT, RETURNING)
+// and by Field values with their own arguments are taken into account. An
+// InsertQuery without a ValuesList (e.g. INSERT ... SELECT) is a single batch.
+func (q InsertQuery) batches(batchSize int) []qx.ValuesList {
+	if len(q.ValuesList) == 0 {
+		return []qx.ValuesList{q.ValuesList}
+	}
+	rows := q.ValuesList
+	q.ValuesList = nil
+	q.Log = nil
+	if q.Mapper != nil {
+		r := &QyRow{QxRow: &qx.QxRow{}}
+		q.Mapper(r)
+		q.ReturningFields = r.QxRow.Fields
+	}
+	_, fixedArgs := q.ToSQL()
+	maxParams := MaxBindParams - len(fixedArgs)
+	var batches []qx.ValuesList
+	start, params := 0, 0
+	for i := range rows {
+		var rowArgs []interface{}
+		qx.ValuesList{rows[i]}.WriteSQL(&strings.Builder{}, &rowArgs, "", "")
+		if i > start && (params+len(rowArgs) > maxParams || (batchSize > 0 && i-start >= batchSize)) {
+			batches = append(batches, rows[start:i])
+			start, params = i, 0
+		}
+		params += len(rowArgs)
+	}
+	return append(batches, rows[start:])
+}
+
 func (q InsertQuery) As(alias string) InsertQuery {
 	q.Alias = alias
 	return q
@@ -312,3 +312,43 @@ func TestInsertMisc1(t *testing.T) {
 // 	}).Exec(db)
 // 	fmt.Println(users)
 // }
+
+func TestInsertQuery_Batches(t *testing.T) {
+	is := is.New(t)
+	f := tables.FILM()
+	q := InsertInto(f).Columns(f.FILM_ID, f.TITLE)
+	for i := 1; i <= 5; i++ {
+		q = q.Values(i, "title")
+	}
+	batches := q.batches(2)
+	is.Equal(3, len(batches))
+	is.Equal(qx.ValuesList{{5, "title"}}, batches[2])
+	q.ValuesList = batches[2]
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal("INSERT INTO film (film_id, title) VALUES ($1, $2)", gotQuery)
+	is.Equal([]interface{}{5, "title"}, gotArgs)
+	// a batch size of 0 fits as many rows as the bind parameter limit allows
+	is.Equal(1, len(q.batches(0)))
+	for i := 0; i < MaxBindParams/2; i++ {
+		q = q.Values(i, "title")
+	}
+	batches = q.batches(0)
+	is.Equal(2, len(batches))
+	is.Equal(MaxBindParams/2, len(batches[0]))
+	// the arguments outside of the VALUES count towards the limit
+	q = q.OnConflict(f.FILM_ID).DoUpdateSet(f.TITLE.SetString("x"), f.LENGTH.SetInt(1))
+	batches = q.batches(0)
+	is.Equal(2, len(batches))
+	is.Equal((MaxBindParams-2)/2, len(batches[0]))
+	// so do Field values with their own arguments
+	q = InsertInto(f).Columns(f.FILM_ID, f.TITLE)
+	for i := 0; i <= MaxBindParams/3; i++ {
+		q = q.Values(i, qx.String("a").Concat("b"))
+	}
+	is.Equal(2, len(q.batches(0)))
+	// INSERT ... SELECT is a single batch
+	is.Equal(1, len(InsertInto(f).Select(Select(f.FILM_ID).From(f)).batches(2)))
+	// without an accumulator only a single row could be fetched
+	err := q.Returningx(func(Row) {}, nil).FetchBatched(nil, &sql.DB{}, 2)
+	is.True(err != nil)
+}
@@ -0,0 +1,489 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+// MergeQuery represents a MERGE statement (postgres 15+). RETURNING requires
+// postgres 17+.
+type MergeQuery struct {
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
+	// WITH
+	CTEs qx.CTEs
+	// MERGE INTO
+	IntoTable qx.BaseTable
+	// USING
+	UsingTable   qx.Table
+	OnPredicates qx.VariadicPredicate
+	// WHEN
+	WhenClauses []MergeWhen
+	// RETURNING
+	ReturningFields qx.Fields
+	// DB
+	DB          qx.DB
+	Mapper      func(Row)
+	Accumulator func()
+	// Logging
+	Log     qx.Logger
+	LogFlag int
+	LogSkip int
+}
+
+// MergeWhen represents a single WHEN clause of a MERGE statement. The action
+// is DELETE if Delete is true, INSERT if Insert is true, UPDATE if there are
+// SetFields and DO NOTHING otherwise.
+type MergeWhen struct {
+	// MATCHED, NOT MATCHED or NOT MATCHED BY SOURCE
+	Condition string
+	// AND
+	Predicates qx.VariadicPredicate
+	// THEN UPDATE SET
+	SetFields qx.FieldValueSets
+	// THEN DELETE
+	Delete bool
+	// THEN INSERT
+	Insert       bool
+	InsertFields qx.Fields
+	InsertValues []interface{}
+}
+
+func (q MergeQuery) ToSQL() (string, []interface{}) {
+	var buf = &strings.Builder{}
+	var args []interface{}
+	var excludeTableQualifiers []string
+	// WITH
+	q.CTEs.WriteSQL(buf, &args)
+	{ // MERGE INTO
+		intoQuery, intoArgs := "", []interface{}{}
+		if q.IntoTable != nil {
+			intoQuery, intoArgs = q.IntoTable.ToSQL()
+			if q.IntoTable.GetAlias() != "" {
+				excludeTableQualifiers = append(excludeTableQualifiers, q.IntoTable.GetAlias())
+			} else if q.IntoTable.GetName() != "" {
+				excludeTableQualifiers = append(excludeTableQualifiers, q.IntoTable.GetName())
+			}
+		}
+		if intoQuery != "" {
+			if buf.Len() > 0 {
+				buf.WriteString(" ")
+			}
+			if q.IntoTable.GetAlias() != "" {
+				buf.WriteString("MERGE INTO " + intoQuery + " AS " + qx.TableAlias(q.IntoTable))
+			} else {
+				buf.WriteString("MERGE INTO " + intoQuery)
+			}
+			args = append(args, intoArgs...)
+		}
+	}
+	{ // USING
+		usingQuery, usingArgs := "", []interface{}{}
+		if q.UsingTable != nil {
+			if query, ok := q.UsingTable.(qx.Query); ok {
+				usingQuery, usingArgs = query.NestThis().ToSQL()
+				usingQuery = "(" + usingQuery + ")"
+			} else {
+				usingQuery, usingArgs = q.UsingTable.ToSQL()
+			}
+		}
+		if usingQuery != "" {
+			if buf.Len() > 0 {
+				buf.WriteString(" ")
+			}
+			if q.UsingTable.GetAlias() != "" {
+				buf.WriteString("USING " + usingQuery + " AS " + qx.TableAlias(q.UsingTable))
+			} else {
+				buf.WriteString("USING " + usingQuery)
+			}
+			args = append(args, usingArgs...)
+		}
+	}
+	// ON
+	q.OnPredicates.Toplevel = true
+	q.OnPredicates.WriteSQL(buf, &args, "ON ", "", nil)
+	// WHEN
+	for _, when := range q.WhenClauses {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("WHEN " + when.Condition)
+		when.Predicates.Toplevel = true
+		when.Predicates.WriteSQL(buf, &args, "AND ", "", nil)
+		switch {
+		case when.Delete:
+			buf.WriteString(" THEN DELETE")
+		case when.Insert:
+			if !when.InsertFields.WriteSQL(buf, &args, "THEN INSERT (", ")", excludeTableQualifiers) {
+				buf.WriteString(" THEN INSERT DEFAULT VALUES")
+				break
+			}
+			qx.ValuesList{when.InsertValues}.WriteSQL(buf, &args, "VALUES ", "")
+		case len(when.SetFields) > 0:
+			buf.WriteString(" THEN UPDATE SET ")
+			writeMergeSets(buf, &args, when.SetFields, excludeTableQualifiers)
+		default:
+			buf.WriteString(" THEN DO NOTHING")
+		}
+	}
+	// RETURNING
+	q.ReturningFields.WriteSQLWithAlias(buf, &args, "RETURNING ", "", nil)
+	query := buf.String()
+	if !q.Nested {
+		if q.Dialect == nil {
+			q.Dialect = qx.Postgres
+		}
+		query = qx.Rebind(q.Dialect, query)
+		if q.Log != nil {
+			var logOutput string
+			switch {
+			case LStats&q.LogFlag != 0:
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
+			case LInterpolate&q.LogFlag != 0:
+				logOutput = "Executing query: " + q.Dialect.InterpolateSQL(query, args...)
+			default:
+				logOutput = "Executing query: " + query + " " + fmt.Sprint(args)
+			}
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logOutput)
+			default:
+				q.Log.Output(q.LogSkip+1, logOutput)
+			}
+		}
+	}
+	return query, args
+}
+
+// writeMergeSets writes the SET assignments of a WHEN MATCHED THEN UPDATE
+// clause. Unlike FieldValueSets.WriteSQL, the target table qualifiers are only
+// excluded from the column being set and not from the value, because an
+// unqualified column in the value could also refer to the source table.
+func writeMergeSets(buf *strings.Builder, args *[]interface{}, sets qx.FieldValueSets, excludeTableQualifiers []string) {
+	for i := range sets {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fieldQuery, fieldArgs := sets[i].Field.ToSQLExclude(excludeTableQualifiers)
+		buf.WriteString(fieldQuery + " = ")
+		*args = append(*args, fieldArgs...)
+		if field, ok := sets[i].Value.(qx.Field); ok && field != nil {
+			valueQuery, valueArgs := field.ToSQLExclude(nil)
+			buf.WriteString(valueQuery)
+			*args = append(*args, valueArgs...)
+		} else {
+			buf.WriteString("?")
+			*args = append(*args, sets[i].Value)
+		}
+	}
+}
+
+// ToSQLDialect marshals the MergeQuery into an SQL query for the given dialect.
+func (q MergeQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
+func (q MergeQuery) GetAlias() string {
+	return q.Alias
+}
+
+func (q MergeQuery) GetName() string {
+	return ""
+}
+
+func (q MergeQuery) NestThis() qx.Query {
+	q.Nested = true
+	return q
+}
+
+func (q MergeQuery) As(alias string) MergeQuery {
+	q.Alias = alias
+	return q
+}
+
+func MergeInto(table qx.BaseTable) MergeQuery {
+	return MergeQuery{
+		IntoTable: table,
+		Alias:     qx.RandomString(8),
+	}
+}
+
+func (q MergeQuery) With(cteList ...qx.CTE) MergeQuery {
+	q.CTEs = append(q.CTEs, cteList...)
+	return q
+}
+
+func (q MergeQuery) MergeInto(tbl qx.BaseTable) MergeQuery {
+	q.IntoTable = tbl
+	return q
+}
+
+func (q MergeQuery) Using(tbl qx.Table, predicate qx.Predicate, predicates ...qx.Predicate) MergeQuery {
+	q.UsingTable = tbl
+	q.OnPredicates.Predicates = append([]qx.Predicate{predicate}, predicates...)
+	return q
+}
+
+func (q MergeQuery) WhenMatched(predicates ...qx.Predicate) mergeMatched {
+	return mergeMatched{mergeQuery: &q, when: MergeWhen{
+		Condition:  "MATCHED",
+		Predicates: qx.VariadicPredicate{Predicates: predicates},
+	}}
+}
+
+// WhenNotMatchedBySource adds a WHEN NOT MATCHED BY SOURCE clause, which
+// applies to target rows that have no matching source row (postgres 17+).
+func (q MergeQuery) WhenNotMatchedBySource(predicates ...qx.Predicate) mergeMatched {
+	return mergeMatched{mergeQuery: &q, when: MergeWhen{
+		Condition:  "NOT MATCHED BY SOURCE",
+		Predicates: qx.VariadicPredicate{Predicates: predicates},
+	}}
+}
+
+func (q MergeQuery) WhenNotMatched(predicates ...qx.Predicate) mergeNotMatched {
+	return mergeNotMatched{mergeQuery: &q, when: MergeWhen{
+		Condition:  "NOT MATCHED",
+		Predicates: qx.VariadicPredicate{Predicates: predicates},
+	}}
+}
+
+// mergeMatched is a WHEN clause that applies to target rows, which can only
+// be updated or deleted.
+type mergeMatched struct {
+	mergeQuery *MergeQuery
+	when       MergeWhen
+}
+
+func (m mergeMatched) ThenUpdate(sets ...qx.FieldValueSet) MergeQuery {
+	if m.mergeQuery == nil {
+		return MergeQuery{}
+	}
+	m.when.SetFields = sets
+	whens := m.mergeQuery.WhenClauses
+	m.mergeQuery.WhenClauses = append(whens[:len(whens):len(whens)], m.when)
+	return *m.mergeQuery
+}
+
+func (m mergeMatched) ThenDelete() MergeQuery {
+	if m.mergeQuery == nil {
+		return MergeQuery{}
+	}
+	m.when.Delete = true
+	whens := m.mergeQuery.WhenClauses
+	m.mergeQuery.WhenClauses = append(whens[:len(whens):len(whens)], m.when)
+	return *m.mergeQuery
+}
+
+func (m mergeMatched) ThenDoNothing() MergeQuery {
+	if m.mergeQuery == nil {
+		return MergeQuery{}
+	}
+	whens := m.mergeQuery.WhenClauses
+	m.mergeQuery.WhenClauses = append(whens[:len(whens):len(whens)], m.when)
+	return *m.mergeQuery
+}
+
+// mergeNotMatched is a WHEN NOT MATCHED clause that applies to source rows,
+// which can only be inserted.
+type mergeNotMatched struct {
+	mergeQuery *MergeQuery
+	when       MergeWhen
+}
+
+// ThenInsert inserts the source row. If no sets are given, the row is
+// inserted with DEFAULT VALUES.
+func (m mergeNotMatched) ThenInsert(sets ...qx.FieldValueSet) MergeQuery {
+	if m.mergeQuery == nil {
+		return MergeQuery{}
+	}
+	m.when.Insert = true
+	for i := range sets {
+		m.when.InsertFields = append(m.when.InsertFields, sets[i].Field)
+		m.when.InsertValues = append(m.when.InsertValues, sets[i].Value)
+	}
+	whens := m.mergeQuery.WhenClauses
+	m.mergeQuery.WhenClauses = append(whens[:len(whens):len(whens)], m.when)
+	return *m.mergeQuery
+}
+
+func (m mergeNotMatched) ThenDoNothing() MergeQuery {
+	if m.mergeQuery == nil {
+		return MergeQuery{}
+	}
+	whens := m.mergeQuery.WhenClauses
+	m.mergeQuery.WhenClauses = append(whens[:len(whens):len(whens)], m.when)
+	return *m.mergeQuery
+}
+
+// MergeAction returns a new StringField representing the 'merge_action()'
+// function, which is only valid in the RETURNING clause of a MERGE statement
+// (postgres 17+). It evaluates to 'INSERT', 'UPDATE' or 'DELETE'.
+func MergeAction() qx.StringField {
+	return qx.StringFieldf("merge_action()")
+}
+
+func (q MergeQuery) Returning(fields ...qx.Field) MergeQuery {
+	q.ReturningFields = append(q.ReturningFields, fields...)
+	return q
+}
+
+func (q MergeQuery) ReturningOne() MergeQuery {
+	q.ReturningFields = qx.Fields{qx.FieldLiteral("1")}
+	return q
+}
+
+func (q MergeQuery) Returningx(mapper func(Row), accumulator func()) MergeQuery {
+	q.Mapper = mapper
+	q.Accumulator = accumulator
+	return q
+}
+
+func (q MergeQuery) ReturningRowx(mapper func(Row)) MergeQuery {
+	q.Mapper = mapper
+	return q
+}
+
+func (q MergeQuery) Fetch(db qx.DB) (err error) {
+	q.LogSkip += 1
+	return q.FetchContext(nil, db)
+}
+
+func (q MergeQuery) FetchContext(ctx context.Context, db qx.DB) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	logBuf := &strings.Builder{}
+	var rowcount int
+	defer func() func() {
+		var logskip int
+		switch q.Log.(type) {
+		case *log.Logger:
+			logskip = q.LogSkip + 2
+		default:
+			logskip = q.LogSkip + 1
+		}
+		start := time.Now()
+		return func() {
+			elapsed := time.Since(start)
+			if LResults&q.LogFlag != 0 && q.Log != nil && rowcount > 5 {
+				logBuf.WriteString("\n...")
+			}
+			if LStats&q.LogFlag != 0 && q.Log != nil {
+				logBuf.WriteString("\n(Fetched " + strconv.Itoa(rowcount) + " rows in " + elapsed.String() + ")")
+			}
+			if logBuf.Len() > 0 && q.Log != nil {
+				q.Log.Output(logskip, logBuf.String())
+			}
+		}
+	}()()
+	if db == nil {
+		if q.DB == nil {
+			return errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	r := &QyRow{QxRow: &qx.QxRow{}}
+	if q.Mapper != nil {
+		q.Mapper(r) // call the mapper once on the *Row to get all the selected that the user is interested in
+	}
+	q.ReturningFields = r.QxRow.Fields // then, transfer the selected collected by *Row to the MergeQuery
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		r.QxRow.Rows, err = db.Query(query, args...)
+	} else {
+		r.QxRow.Rows, err = db.QueryContext(ctx, query, args...)
+	}
+	if err != nil {
+		return err
+	}
+	defer r.QxRow.Rows.Close()
+	if len(r.QxRow.Dest) == 0 {
+		// If there's nothing to scan into, return early
+		return nil
+	}
+	for r.QxRow.Rows.Next() {
+		rowcount++
+		err = r.QxRow.Rows.Scan(r.QxRow.Dest...)
+		if err != nil {
+			buf := &strings.Builder{}
+			for i := range r.QxRow.Dest {
+				query, args := r.QxRow.Fields[i].ToSQLExclude(nil)
+				buf.WriteString("\n" +
+					strconv.Itoa(i) + ") " +
+					qx.MySQLInterpolateSQL(query, args...) + " => " +
+					reflect.TypeOf(r.QxRow.Dest[i]).String())
+			}
+			return fmt.Errorf("Please check if your mapper function is correct:%s\n%w", buf.String(), err)
+		}
+		if LResults&q.LogFlag != 0 && q.Log != nil && rowcount <= 5 {
+			logBuf.WriteString("\n----[ Row " + strconv.Itoa(rowcount) + " ]----")
+			for i := range r.QxRow.Dest {
+				q, a := r.QxRow.Fields[i].ToSQLExclude(nil)
+				logBuf.WriteString("\n" + qx.MySQLInterpolateSQL(q, a...) + ": " + qx.ArgToStringV2(r.QxRow.Dest[i]))
+			}
+		}
+		r.QxRow.Index = 0 // index must always be reset back to 0 before mapper is called
+		q.Mapper(r)
+		if q.Accumulator == nil {
+			break
+		}
+		q.Accumulator()
+	}
+	if rowcount == 0 && q.Accumulator == nil {
+		return sql.ErrNoRows
+	}
+	return r.QxRow.Rows.Err()
+}
+
+func (q MergeQuery) Exec(db qx.DB) (sql.Result, error) {
+	q.LogSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+func (q MergeQuery) ExecContext(ctx context.Context, db qx.DB) (res sql.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	if db == nil {
+		if q.DB == nil {
+			return res, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		res, err = db.Exec(query, args...)
+	} else {
+		res, err = db.ExecContext(ctx, query, args...)
+	}
+	return res, err
+}
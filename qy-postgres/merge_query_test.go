@@ -0,0 +1,92 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/bokwoon95/qy/qx"
+	"github.com/bokwoon95/qy/tables-postgres"
+	"github.com/matryer/is"
+)
+
+func TestMergeQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           MergeQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	f := tables.FILM()
+	v := Values([]interface{}{1, "Academy Dinosaur"}).As("v", "id", "title")
+	merge := MergeInto(f).Using(v, f.FILM_ID.Eq(v.Number("id")))
+	tests := []TT{
+		{
+			"upsert",
+			merge.
+				WhenMatched().ThenUpdate(f.TITLE.Set(v.String("title"))).
+				WhenNotMatched().ThenInsert(f.FILM_ID.Set(v.Number("id")), f.TITLE.Set(v.String("title"))),
+			"MERGE INTO film USING (VALUES ($1, $2)) AS v (id, title) ON film.film_id = v.id" +
+				" WHEN MATCHED THEN UPDATE SET title = v.title" +
+				" WHEN NOT MATCHED THEN INSERT (film_id, title) VALUES (v.id, v.title)",
+			[]interface{}{1, "Academy Dinosaur"},
+		},
+		{
+			"conditional update, delete and do nothing",
+			merge.
+				WhenMatched(v.String("title").IsNull()).ThenDelete().
+				WhenMatched(f.TITLE.Eq(v.String("title"))).ThenDoNothing().
+				WhenMatched().ThenUpdate(f.TITLE.Set(v.String("title")), f.LENGTH.Set(f.LENGTH.Add(1))).
+				WhenNotMatched().ThenDoNothing(),
+			"MERGE INTO film USING (VALUES ($1, $2)) AS v (id, title) ON film.film_id = v.id" +
+				" WHEN MATCHED AND v.title IS NULL THEN DELETE" +
+				" WHEN MATCHED AND film.title = v.title THEN DO NOTHING" +
+				" WHEN MATCHED THEN UPDATE SET title = v.title, length = (film.length + $3)" +
+				" WHEN NOT MATCHED THEN DO NOTHING",
+			[]interface{}{1, "Academy Dinosaur", 1},
+		},
+		{
+			"subquery source, NOT MATCHED BY SOURCE and RETURNING",
+			MergeInto(f).
+				Using(Select(f.FILM_ID).From(f).Where(f.LENGTH.GtInt(100)).As("src"), f.FILM_ID.Eq(qx.NumberFieldf("src.film_id"))).
+				WhenNotMatched().ThenInsert().
+				WhenNotMatchedBySource().ThenDelete().
+				Returning(MergeAction(), f.FILM_ID),
+			"MERGE INTO film USING (SELECT film.film_id FROM film WHERE film.length > $1) AS src ON film.film_id = src.film_id" +
+				" WHEN NOT MATCHED THEN INSERT DEFAULT VALUES" +
+				" WHEN NOT MATCHED BY SOURCE THEN DELETE" +
+				" RETURNING merge_action(), film.film_id",
+			[]interface{}{100},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestMergeQuery_SharedBase(t *testing.T) {
+	is := is.New(t)
+	f := tables.FILM()
+	v := Values([]interface{}{1, "Academy Dinosaur"}).As("v", "id", "title")
+	base := MergeInto(f).Using(v, f.FILM_ID.Eq(v.Number("id"))).
+		WhenMatched(v.String("title").IsNull()).ThenDelete().
+		WhenMatched(f.TITLE.Eq(v.String("title"))).ThenDoNothing().
+		WhenMatched().ThenUpdate(f.TITLE.Set(v.String("title")))
+	a := base.WhenNotMatched().ThenInsert(f.FILM_ID.Set(v.Number("id")))
+	b := base.WhenNotMatched().ThenDoNothing()
+	prefix := "MERGE INTO film USING (VALUES ($1, $2)) AS v (id, title) ON film.film_id = v.id" +
+		" WHEN MATCHED AND v.title IS NULL THEN DELETE" +
+		" WHEN MATCHED AND film.title = v.title THEN DO NOTHING" +
+		" WHEN MATCHED THEN UPDATE SET title = v.title"
+	gotQuery, _ := a.ToSQL()
+	is.Equal(prefix+" WHEN NOT MATCHED THEN INSERT (film_id) VALUES (v.id)", gotQuery)
+	gotQuery, _ = b.ToSQL()
+	is.Equal(prefix+" WHEN NOT MATCHED THEN DO NOTHING", gotQuery)
+	gotQuery, _ = base.ToSQL()
+	is.Equal(prefix, gotQuery)
+}
@@ -6,14 +6,18 @@ import (
 	"github.com/bokwoon95/qy/qx"
 )
 
-func Array(slice interface{}) qx.ArrayField { return qx.Array(slice) }
-func Bytes(b []byte) qx.BinaryField         { return qx.Bytes(b) }
-func Bool(b bool) qx.BooleanField           { return qx.Bool(b) }
-func Int(num int) qx.NumberField            { return qx.Int(num) }
-func Int64(num int64) qx.NumberField        { return qx.Int64(num) }
-func Float64(num float64) qx.NumberField    { return qx.Float64(num) }
-func String(s string) qx.StringField        { return qx.String(s) }
-func Time(t time.Time) qx.TimeField         { return qx.Time(t) }
+func Array(slice interface{}) qx.ArrayField       { return qx.Array(slice) }
+func Values(rows ...[]interface{}) qx.ValuesTable { return qx.Values(rows...) }
+func ArrayAgg(field qx.Field) qx.ArrayField       { return qx.ArrayAgg(field) }
+func Bytes(b []byte) qx.BinaryField               { return qx.Bytes(b) }
+func Bool(b bool) qx.BooleanField                 { return qx.Bool(b) }
+func Int(num int) qx.NumberField                  { return qx.Int(num) }
+func Int64(num int64) qx.NumberField              { return qx.Int64(num) }
+func Float64(num float64) qx.NumberField          { return qx.Float64(num) }
+func String(s string) qx.StringField              { return qx.String(s) }
+func Time(t time.Time) qx.TimeField               { return qx.Time(t) }
+func Now() qx.TimeField                           { return qx.Now() }
+func CurrentDate() qx.TimeField                   { return qx.CurrentDate() }
 
 type Table = qx.Table
 type Query = qx.Query
@@ -26,6 +30,43 @@ type ValuesList = qx.ValuesList
 type Queryer = qx.Queryer
 type QueryerContext = qx.QueryerContext
 type Logger = qx.Logger
+type Window = qx.Window
+type Windows = qx.Windows
+type WindowFrame = qx.WindowFrame
+
+func RowNumber() qx.NumberField                 { return qx.RowNumber() }
+func Rank() qx.NumberField                      { return qx.Rank() }
+func DenseRank() qx.NumberField                 { return qx.DenseRank() }
+func Lag(field qx.Field, n int) qx.NumberField  { return qx.Lag(field, n) }
+func Lead(field qx.Field, n int) qx.NumberField { return qx.Lead(field, n) }
+func FirstValue(field qx.Field) qx.NumberField  { return qx.FirstValue(field) }
+func LastValue(field qx.Field) qx.NumberField   { return qx.LastValue(field) }
+func NthValue(field qx.Field, n int) qx.NumberField {
+	return qx.NthValue(field, n)
+}
+
+func CountAll() qx.NumberField                      { return qx.CountAll() }
+func Count(field qx.Field) qx.NumberField           { return qx.Count(field) }
+func CountDistinct(field qx.Field) qx.NumberField   { return qx.CountDistinct(field) }
+func Sum(field qx.NumberField) qx.NumberField       { return qx.Sum(field) }
+func Avg(field qx.NumberField) qx.NumberField       { return qx.Avg(field) }
+func Min(field qx.NumberField) qx.NumberField       { return qx.Min(field) }
+func Max(field qx.NumberField) qx.NumberField       { return qx.Max(field) }
+func MinString(field qx.StringField) qx.StringField { return qx.MinString(field) }
+func MaxString(field qx.StringField) qx.StringField { return qx.MaxString(field) }
+func MinTime(field qx.TimeField) qx.TimeField       { return qx.MinTime(field) }
+func MaxTime(field qx.TimeField) qx.TimeField       { return qx.MaxTime(field) }
+func BoolAnd(field qx.BooleanField) qx.BooleanField { return qx.BoolAnd(field) }
+func BoolOr(field qx.BooleanField) qx.BooleanField  { return qx.BoolOr(field) }
+func JSONAgg(field qx.Field) qx.JSONField           { return qx.JSONAgg(field) }
+func StringAgg(field qx.StringField, separator string) qx.StringField {
+	return qx.StringAgg(field, separator)
+}
+
+func Rollup(fields ...qx.Field) qx.CustomField      { return qx.Rollup(fields...) }
+func Cube(fields ...qx.Field) qx.CustomField        { return qx.Cube(fields...) }
+func GroupingSets(sets ...qx.Fields) qx.CustomField { return qx.GroupingSets(sets...) }
+func Grouping(fields ...qx.Field) qx.NumberField    { return qx.Grouping(fields...) }
 
 func NewCTE(name string, query qx.Query) qx.CTE {
 	return qx.CTE{
@@ -34,6 +75,10 @@ func NewCTE(name string, query qx.Query) qx.CTE {
 	}
 }
 
+func RecursiveCTE(name string, columns ...string) qx.CTE {
+	return qx.RecursiveCTE(name, columns...)
+}
+
 func And(predicates ...qx.Predicate) qx.Predicate {
 	return qx.VariadicPredicate{
 		Operator:   qx.PredicateAnd,
@@ -48,6 +93,14 @@ func Or(predicates ...qx.Predicate) qx.Predicate {
 	}
 }
 
+func Case(field qx.Field) qx.CaseExpr {
+	return qx.Case(field)
+}
+
+func CaseWhen(predicate qx.Predicate, result interface{}) qx.CaseExpr {
+	return qx.CaseWhen(predicate, result)
+}
+
 func Fieldf(format string, values ...interface{}) qx.CustomField {
 	return qx.CustomField{
 		Format: format,
@@ -69,3 +122,105 @@ func Queryf(format string, values ...interface{}) qx.CustomQuery {
 		Values:   values,
 	}
 }
+
+func EscapeLike(s string) string { return qx.EscapeLike(s) }
+
+func Coalesce(field qx.Field, values ...interface{}) qx.CustomField {
+	return qx.Coalesce(field, values...)
+}
+
+func CoalesceNumber(field qx.NumberField, values ...interface{}) qx.NumberField {
+	return qx.CoalesceNumber(field, values...)
+}
+
+func CoalesceString(field qx.StringField, values ...interface{}) qx.StringField {
+	return qx.CoalesceString(field, values...)
+}
+
+func CoalesceTime(field qx.TimeField, values ...interface{}) qx.TimeField {
+	return qx.CoalesceTime(field, values...)
+}
+
+func CoalesceBoolean(field qx.BooleanField, values ...interface{}) qx.BooleanField {
+	return qx.CoalesceBoolean(field, values...)
+}
+
+func NullIf(field qx.Field, value interface{}) qx.CustomField {
+	return qx.NullIf(field, value)
+}
+
+func NullIfNumber(field qx.NumberField, value interface{}) qx.NumberField {
+	return qx.NullIfNumber(field, value)
+}
+
+func NullIfString(field qx.StringField, value interface{}) qx.StringField {
+	return qx.NullIfString(field, value)
+}
+
+func NullIfTime(field qx.TimeField, value interface{}) qx.TimeField {
+	return qx.NullIfTime(field, value)
+}
+
+func NullIfBoolean(field qx.BooleanField, value interface{}) qx.BooleanField {
+	return qx.NullIfBoolean(field, value)
+}
+
+func Greatest(field qx.Field, values ...interface{}) qx.CustomField {
+	return qx.Greatest(field, values...)
+}
+
+func GreatestNumber(field qx.NumberField, values ...interface{}) qx.NumberField {
+	return qx.GreatestNumber(field, values...)
+}
+
+func GreatestString(field qx.StringField, values ...interface{}) qx.StringField {
+	return qx.GreatestString(field, values...)
+}
+
+func GreatestTime(field qx.TimeField, values ...interface{}) qx.TimeField {
+	return qx.GreatestTime(field, values...)
+}
+
+func GreatestBoolean(field qx.BooleanField, values ...interface{}) qx.BooleanField {
+	return qx.GreatestBoolean(field, values...)
+}
+
+func Least(field qx.Field, values ...interface{}) qx.CustomField {
+	return qx.Least(field, values...)
+}
+
+func LeastNumber(field qx.NumberField, values ...interface{}) qx.NumberField {
+	return qx.LeastNumber(field, values...)
+}
+
+func LeastString(field qx.StringField, values ...interface{}) qx.StringField {
+	return qx.LeastString(field, values...)
+}
+
+func LeastTime(field qx.TimeField, values ...interface{}) qx.TimeField {
+	return qx.LeastTime(field, values...)
+}
+
+func LeastBoolean(field qx.BooleanField, values ...interface{}) qx.BooleanField {
+	return qx.LeastBoolean(field, values...)
+}
+
+func Cast(field qx.Field, typ string) qx.CustomField {
+	return qx.Cast(field, typ)
+}
+
+func CastNumber(field qx.Field, typ string) qx.NumberField {
+	return qx.CastNumber(field, typ)
+}
+
+func CastString(field qx.Field, typ string) qx.StringField {
+	return qx.CastString(field, typ)
+}
+
+func CastTime(field qx.Field, typ string) qx.TimeField {
+	return qx.CastTime(field, typ)
+}
+
+func CastBoolean(field qx.Field, typ string) qx.BooleanField {
+	return qx.CastBoolean(field, typ)
+}
@@ -104,7 +104,7 @@ func (qy BaseQuery) SelectAll() SelectQuery {
 
 func (qy BaseQuery) SelectCount() SelectQuery {
 	return SelectQuery{
-		SelectFields: qx.Fields{qx.FieldLiteral("COUNT(*)")},
+		SelectFields: qx.Fields{qx.CountAll()},
 		Alias:        qx.RandomString(8),
 		CTEs:         qy.CTEs,
 		DB:           qy.DB,
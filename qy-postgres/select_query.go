@@ -15,8 +15,9 @@ import (
 )
 
 type SelectQuery struct {
-	Nested bool
-	Alias  string
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
 	// WITH
 	CTEs qx.CTEs
 	// SELECT
@@ -32,12 +33,16 @@ type SelectQuery struct {
 	GroupByFields qx.Fields
 	// HAVING
 	HavingPredicates qx.VariadicPredicate
+	// WINDOW
+	Windows qx.Windows
 	// ORDER BY
 	OrderByFields qx.Fields
 	// LIMIT
 	LimitValue *uint64
 	// OFFSET
 	OffsetValue *uint64
+	// FOR UPDATE
+	Locks qx.RowLocks
 	// DB
 	DB          qx.DB
 	Mapper      func(Row)
@@ -73,7 +78,9 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 	}
 	{ // FROM
 		fromQuery, fromArgs := "", []interface{}{}
-		if q.FromTable != nil {
+		if query, ok := q.FromTable.(qx.Query); ok {
+			fromQuery, fromArgs = query.NestThis().ToSQL()
+		} else if q.FromTable != nil {
 			fromQuery, fromArgs = q.FromTable.ToSQL()
 		}
 		if fromQuery != "" {
@@ -84,7 +91,7 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 				fromQuery = "(" + fromQuery + ")"
 			}
 			if q.FromTable.GetAlias() != "" {
-				buf.WriteString("FROM " + fromQuery + " AS " + q.FromTable.GetAlias())
+				buf.WriteString("FROM " + fromQuery + " AS " + qx.TableAlias(q.FromTable))
 			} else {
 				buf.WriteString("FROM " + fromQuery)
 			}
@@ -101,6 +108,8 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 	// HAVING
 	q.HavingPredicates.Toplevel = true
 	q.HavingPredicates.WriteSQL(buf, &args, "HAVING ", "", nil)
+	// WINDOW
+	q.Windows.WriteSQL(buf, &args, nil)
 	// ORDER BY
 	q.OrderByFields.WriteSQL(buf, &args, "ORDER BY ", "", nil)
 	// LIMIT
@@ -119,17 +128,29 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 		buf.WriteString("OFFSET ?")
 		args = append(args, *q.OffsetValue)
 	}
+	// FOR UPDATE
+	if q.Locks.WriteSQL(buf) && q.Nested {
+		// A nested SelectQuery (subquery, CTE or compound query branch) has no
+		// Fetch or Exec of its own to return the error, so it panics and the
+		// Fetch or Exec of the outermost query recovers it.
+		if err := q.checkLocks(); err != nil {
+			panic(err)
+		}
+	}
 	query := buf.String()
 	if !q.Nested {
-		query = qx.MySQLToPostgresPlaceholders(query)
+		if q.Dialect == nil {
+			q.Dialect = qx.Postgres
+		}
+		query = qx.Rebind(q.Dialect, query)
 		if q.Log != nil {
 			var logOutput string
 			switch {
 			case LStats&q.LogFlag != 0:
 				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
-					"\n----[ with bind values ]----\n" + qx.PostgresInterpolateSQL(query, args...)
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
 			case LInterpolate&q.LogFlag != 0:
-				logOutput = qx.PostgresInterpolateSQL(query, args...)
+				logOutput = q.Dialect.InterpolateSQL(query, args...)
 			default:
 				logOutput = query + " " + fmt.Sprint(args)
 			}
@@ -144,6 +165,13 @@ func (q SelectQuery) ToSQL() (string, []interface{}) {
 	return query, args
 }
 
+// ToSQLDialect marshals the SelectQuery into an SQL query for the given dialect.
+func (q SelectQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
 func From(table qx.Table) SelectQuery {
 	return SelectQuery{
 		FromTable: table,
@@ -220,7 +248,7 @@ func (q SelectQuery) SelectAll() SelectQuery {
 }
 
 func (q SelectQuery) SelectCount() SelectQuery {
-	q.SelectFields = qx.Fields{qx.FieldLiteral("COUNT(*)")}
+	q.SelectFields = qx.Fields{qx.CountAll()}
 	return q
 }
 
@@ -290,6 +318,43 @@ func (q SelectQuery) CrossJoin(table qx.Table) SelectQuery {
 	return q
 }
 
+func (q SelectQuery) JoinLateral(table qx.Table, predicates ...qx.Predicate) SelectQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeDefault,
+		Lateral:      true,
+		Table:        table,
+		OnPredicates: qx.VariadicPredicate{Predicates: predicates},
+	})
+	return q
+}
+
+func (q SelectQuery) LeftJoinLateral(table qx.Table, predicates ...qx.Predicate) SelectQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeLeft,
+		Lateral:      true,
+		Table:        table,
+		OnPredicates: qx.VariadicPredicate{Predicates: predicates},
+	})
+	return q
+}
+
+func (q SelectQuery) JoinUsing(table qx.Table, fields ...qx.Field) SelectQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:    qx.JoinTypeDefault,
+		Table:       table,
+		UsingFields: fields,
+	})
+	return q
+}
+
+func (q SelectQuery) NaturalJoin(table qx.Table) SelectQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType: qx.JoinTypeNatural,
+		Table:    table,
+	})
+	return q
+}
+
 func (q SelectQuery) Where(predicates ...qx.Predicate) SelectQuery {
 	q.WherePredicates.Predicates = append(q.WherePredicates.Predicates, predicates...)
 	return q
@@ -305,6 +370,11 @@ func (q SelectQuery) Having(predicates ...qx.Predicate) SelectQuery {
 	return q
 }
 
+func (q SelectQuery) Window(windows ...qx.Window) SelectQuery {
+	q.Windows = append(q.Windows, windows...)
+	return q
+}
+
 func (q SelectQuery) OrderBy(fields ...qx.Field) SelectQuery {
 	q.OrderByFields = append(q.OrderByFields, fields...)
 	return q
@@ -328,6 +398,91 @@ func (q SelectQuery) Offset(offset int) SelectQuery {
 	return q
 }
 
+// ForUpdate adds a 'FOR UPDATE' row locking clause to the SelectQuery.
+func (q SelectQuery) ForUpdate() SelectQuery {
+	q.Locks = append(q.Locks[:len(q.Locks):len(q.Locks)], qx.RowLock{Strength: qx.LockForUpdate})
+	return q
+}
+
+// ForNoKeyUpdate adds a 'FOR NO KEY UPDATE' row locking clause to the SelectQuery.
+func (q SelectQuery) ForNoKeyUpdate() SelectQuery {
+	q.Locks = append(q.Locks[:len(q.Locks):len(q.Locks)], qx.RowLock{Strength: qx.LockForNoKeyUpdate})
+	return q
+}
+
+// ForShare adds a 'FOR SHARE' row locking clause to the SelectQuery.
+func (q SelectQuery) ForShare() SelectQuery {
+	q.Locks = append(q.Locks[:len(q.Locks):len(q.Locks)], qx.RowLock{Strength: qx.LockForShare})
+	return q
+}
+
+// ForKeyShare adds a 'FOR KEY SHARE' row locking clause to the SelectQuery.
+func (q SelectQuery) ForKeyShare() SelectQuery {
+	q.Locks = append(q.Locks[:len(q.Locks):len(q.Locks)], qx.RowLock{Strength: qx.LockForKeyShare})
+	return q
+}
+
+// Of restricts the last row locking clause of the SelectQuery to the tables
+// i.e. 'FOR UPDATE OF tables'.
+func (q SelectQuery) Of(tables ...qx.Table) SelectQuery {
+	if len(q.Locks) == 0 {
+		return q
+	}
+	locks := make(qx.RowLocks, len(q.Locks))
+	copy(locks, q.Locks)
+	last := &locks[len(locks)-1]
+	last.Of = append(append([]qx.Table{}, last.Of...), tables...)
+	q.Locks = locks
+	return q
+}
+
+// SkipLocked makes the last row locking clause of the SelectQuery skip rows
+// that are already locked i.e. 'FOR UPDATE SKIP LOCKED'.
+func (q SelectQuery) SkipLocked() SelectQuery {
+	return q.lockWaitPolicy(qx.LockSkipLocked)
+}
+
+// NoWait makes the last row locking clause of the SelectQuery fail instead of
+// waiting for rows that are already locked i.e. 'FOR UPDATE NOWAIT'.
+func (q SelectQuery) NoWait() SelectQuery {
+	return q.lockWaitPolicy(qx.LockNoWait)
+}
+
+func (q SelectQuery) lockWaitPolicy(policy qx.LockWaitPolicy) SelectQuery {
+	if len(q.Locks) == 0 {
+		return q
+	}
+	locks := make(qx.RowLocks, len(q.Locks))
+	copy(locks, q.Locks)
+	locks[len(locks)-1].WaitPolicy = policy
+	q.Locks = locks
+	return q
+}
+
+// checkLocks returns an error if the SelectQuery cannot have row locking
+// clauses, because the rows it returns do not correspond to individual table
+// rows.
+func (q SelectQuery) checkLocks() error {
+	if len(q.Locks) == 0 {
+		return nil
+	}
+	switch {
+	case q.SelectType == qx.SelectTypeDistinct || q.SelectType == qx.SelectTypeDistinctOn:
+		return errors.New("row locking clauses are not allowed with DISTINCT")
+	case len(q.GroupByFields) > 0:
+		return errors.New("row locking clauses are not allowed with GROUP BY")
+	case len(q.HavingPredicates.Predicates) > 0:
+		return errors.New("row locking clauses are not allowed with HAVING")
+	case len(q.Windows) > 0:
+		return errors.New("row locking clauses are not allowed with WINDOW")
+	case qx.ContainsAggregate(q.SelectFields...):
+		return errors.New("row locking clauses are not allowed with aggregate functions")
+	case qx.ContainsWindowFunction(q.SelectFields...):
+		return errors.New("row locking clauses are not allowed with window functions")
+	}
+	return nil
+}
+
 func (q SelectQuery) Selectx(mapper func(Row), accumulator func()) SelectQuery {
 	q.Mapper = mapper
 	q.Accumulator = accumulator
@@ -385,6 +540,9 @@ func (q SelectQuery) FetchContext(ctx context.Context, db qx.DB) (err error) {
 		}
 		db = q.DB
 	}
+	if err = q.checkLocks(); err != nil {
+		return err
+	}
 	r := &QyRow{QxRow: &qx.QxRow{}}
 	if q.Mapper != nil {
 		q.Mapper(r)                     // call the mapper once on the *Row to get all the selected that the user is interested in
@@ -450,15 +608,26 @@ func (q SelectQuery) Exec(db qx.DB) (sql.Result, error) {
 	return q.ExecContext(nil, db)
 }
 
-func (q SelectQuery) ExecContext(ctx context.Context, db qx.DB) (sql.Result, error) {
-	var res sql.Result
-	var err error
+func (q SelectQuery) ExecContext(ctx context.Context, db qx.DB) (res sql.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
 	if db == nil {
 		if q.DB == nil {
 			return res, errors.New("DB cannot be nil")
 		}
 		db = q.DB
 	}
+	if err = q.checkLocks(); err != nil {
+		return res, err
+	}
 	q.LogSkip += 1
 	query, args := q.ToSQL()
 	if ctx == nil {
@@ -1,8 +1,10 @@
 package qy
 
 import (
+	"database/sql"
 	"log"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/bokwoon95/qy/qx"
@@ -282,6 +284,29 @@ func TestSelectQuery_Joins(t *testing.T) {
 			wantQuery := "SELECT sub.address_id FROM (SELECT cust.address_id FROM customer AS cust WHERE cust.store_id > $1) AS sub"
 			return TT{DESCRIPTION, q, wantQuery, []interface{}{4}}
 		}(),
+		func() TT {
+			DESCRIPTION := "lateral join on a correlated subquery"
+			cust, pay := tables.CUSTOMER().As("cust"), tables.PAYMENT()
+			top3 := Select(pay.PAYMENT_ID, pay.AMOUNT).From(pay).
+				Where(pay.CUSTOMER_ID.Eq(cust.CUSTOMER_ID)).
+				OrderBy(pay.AMOUNT.Desc()).
+				Limit(3).
+				As("top3")
+			q := s().From(cust).JoinLateral(top3).LeftJoinLateral(top3, top3.Get("amount").Gt(Int(10))).Select(cust.CUSTOMER_ID, top3.Get("amount"))
+			wantQuery := "SELECT cust.customer_id, top3.amount FROM customer AS cust" +
+				" JOIN LATERAL (SELECT payment.payment_id, payment.amount FROM payment WHERE payment.customer_id = cust.customer_id" +
+				" ORDER BY payment.amount DESC LIMIT $1) AS top3 ON TRUE" +
+				" LEFT JOIN LATERAL (SELECT payment.payment_id, payment.amount FROM payment WHERE payment.customer_id = cust.customer_id" +
+				" ORDER BY payment.amount DESC LIMIT $2) AS top3 ON top3.amount > $3"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{uint64(3), uint64(3), 10}}
+		}(),
+		func() TT {
+			DESCRIPTION := "join using and natural join"
+			cust, addr, city := tables.CUSTOMER().As("cust"), tables.ADDRESS(), tables.CITY()
+			q := s().From(cust).JoinUsing(addr, cust.ADDRESS_ID).NaturalJoin(city)
+			wantQuery := "FROM customer AS cust JOIN address USING (address_id) NATURAL JOIN city"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -391,6 +416,15 @@ func TestSelectQuery_GroupBy(t *testing.T) {
 			wantQuery := "GROUP BY customer.customer_id, customer.store_id, customer.active"
 			return TT{DESCRIPTION, q, wantQuery, nil}
 		}(),
+		func() TT {
+			DESCRIPTION := "group by date_trunc"
+			pay := tables.PAYMENT()
+			day := pay.PAYMENT_DATE.DateTrunc("day")
+			q := s().Select(day, Sum(pay.AMOUNT)).From(pay).GroupBy(day)
+			wantQuery := "SELECT date_trunc('day', payment.payment_date), SUM(payment.amount) FROM payment" +
+				" GROUP BY date_trunc('day', payment.payment_date)"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -567,3 +601,369 @@ func TestSelectQuery_Limit_Offset(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectQuery_ToSQLDialect(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		dialect     qx.Dialect
+		wantQuery   string
+	}
+	cust := tables.CUSTOMER()
+	q := Select(cust.CUSTOMER_ID).From(cust).Where(cust.ACTIVEBOOL.Eq(Bool(true)), cust.FIRST_NAME.EqString("MARY"))
+	tests := []TT{
+		{
+			"Postgres",
+			qx.Postgres,
+			"SELECT customer.customer_id FROM customer WHERE customer.activebool = $1 AND customer.first_name = $2",
+		},
+		{
+			"MySQL",
+			qx.MySQL,
+			"SELECT customer.customer_id FROM customer WHERE customer.activebool = ? AND customer.first_name = ?",
+		},
+		{
+			"Oracle",
+			qx.Oracle,
+			"SELECT customer.customer_id FROM customer WHERE customer.activebool = :1 AND customer.first_name = :2",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := q.ToSQLDialect(tt.dialect)
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal([]interface{}{true, "MARY"}, gotArgs)
+		})
+	}
+}
+
+func TestSelectQuery_Window(t *testing.T) {
+	is := is.New(t)
+	p := tables.PAYMENT()
+	w := Window{Name: "w", PartitionBy: Fields{p.CUSTOMER_ID}, OrderBy: Fields{p.PAYMENT_DATE}}
+	q := Select(p.PAYMENT_ID, RowNumber().Over(w).As("rn"), Lead(p.AMOUNT, 1).Over(w)).
+		From(p).
+		Where(p.AMOUNT.GtFloat64(5)).
+		Window(w).
+		OrderBy(p.PAYMENT_ID)
+	wantQuery := "SELECT payment.payment_id, ROW_NUMBER() OVER w AS rn, LEAD(payment.amount, 1) OVER w" +
+		" FROM payment WHERE payment.amount > $1" +
+		" WINDOW w AS (PARTITION BY payment.customer_id ORDER BY payment.payment_date)" +
+		" ORDER BY payment.payment_id"
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal(wantQuery, gotQuery)
+	is.Equal([]interface{}{5.0}, gotArgs)
+}
+
+func TestSelectQuery_SubqueryPredicates(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           Query
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	cust, pay := tables.CUSTOMER(), tables.PAYMENT()
+	payments := func(amount float64) SelectQuery {
+		return Select(pay.CUSTOMER_ID).From(pay).Where(pay.AMOUNT.GtFloat64(amount))
+	}
+	tests := []TT{
+		{
+			"EXISTS",
+			Select(cust.CUSTOMER_ID).From(cust).Where(
+				cust.STORE_ID.EqInt(1),
+				qx.Exists(Select(pay.PAYMENT_ID).From(pay).Where(pay.CUSTOMER_ID.Eq(cust.CUSTOMER_ID), pay.AMOUNT.GtFloat64(10))),
+			),
+			"SELECT customer.customer_id FROM customer WHERE customer.store_id = $1" +
+				" AND EXISTS (SELECT payment.payment_id FROM payment WHERE payment.customer_id = customer.customer_id AND payment.amount > $2)",
+			[]interface{}{1, 10.0},
+		},
+		{
+			"NOT EXISTS",
+			Select(cust.CUSTOMER_ID).From(cust).Where(qx.NotExists(payments(10))),
+			"SELECT customer.customer_id FROM customer WHERE NOT EXISTS" +
+				" (SELECT payment.customer_id FROM payment WHERE payment.amount > $1)",
+			[]interface{}{10.0},
+		},
+		{
+			"IN and NOT IN",
+			Select(cust.CUSTOMER_ID).From(cust).Where(cust.CUSTOMER_ID.InQuery(payments(10)), cust.STORE_ID.NotIn([]int{3, 4})),
+			"SELECT customer.customer_id FROM customer WHERE customer.customer_id IN" +
+				" (SELECT payment.customer_id FROM payment WHERE payment.amount > $1)" +
+				" AND customer.store_id NOT IN ($2, $3)",
+			[]interface{}{10.0, 3, 4},
+		},
+		{
+			"ANY and ALL",
+			Select(cust.CUSTOMER_ID).From(cust).Where(cust.CUSTOMER_ID.EqAny(payments(5)), cust.CUSTOMER_ID.GtAll(payments(10))),
+			"SELECT customer.customer_id FROM customer WHERE customer.customer_id = ANY" +
+				" (SELECT payment.customer_id FROM payment WHERE payment.amount > $1)" +
+				" AND customer.customer_id > ALL" +
+				" (SELECT payment.customer_id FROM payment WHERE payment.amount > $2)",
+			[]interface{}{5.0, 10.0},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestSelectQuery_RecursiveCTE(t *testing.T) {
+	is := is.New(t)
+	employees := qx.NewTableInfo("public", "employees")
+	employeeID := qx.NewNumberField("employee_id", employees)
+	managerID := qx.NewNumberField("manager_id", employees)
+	name := qx.NewStringField("name", employees)
+	chart := RecursiveCTE("org_chart", "employee_id", "name", "depth")
+	chart = chart.UnionAll(
+		Select(employeeID, name, Int(0)).From(employees).Where(managerID.IsNull()),
+		Select(employeeID, name, qx.NumberFieldf("? + 1", chart.NumberField("depth"))).
+			From(employees).
+			Join(chart, chart.NumberField("employee_id").Eq(managerID)).
+			Where(chart.NumberField("depth").LtInt(5)),
+	)
+	q := Select(chart.StringField("name"), chart.NumberField("depth")).
+		With(chart).
+		From(chart).
+		OrderBy(chart.NumberField("depth"))
+	wantQuery := "WITH RECURSIVE org_chart(employee_id, name, depth) AS" +
+		" (SELECT employees.employee_id, employees.name, $1 FROM employees WHERE employees.manager_id IS NULL" +
+		" UNION ALL" +
+		" SELECT employees.employee_id, employees.name, org_chart.depth + 1 FROM employees" +
+		" JOIN org_chart ON org_chart.employee_id = employees.manager_id WHERE org_chart.depth < $2)" +
+		" SELECT org_chart.name, org_chart.depth FROM org_chart ORDER BY org_chart.depth"
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal(wantQuery, gotQuery)
+	is.Equal([]interface{}{0, 5}, gotArgs)
+}
+
+func TestSelectQuery_Unnest(t *testing.T) {
+	is := is.New(t)
+	film := tables.FILM()
+	features := film.SPECIAL_FEATURES.Unnest().As("feature")
+	feature := features.StringField()
+	q := Select(film.TITLE, feature).
+		From(film).
+		CrossJoin(features).
+		Where(feature.EqAny(Array([]string{"Trailers", "Commentaries"})))
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal("SELECT film.title, feature.feature FROM film CROSS JOIN unnest(film.special_features) AS feature"+
+		" WHERE feature.feature = ANY (ARRAY[$1, $2])", gotQuery)
+	is.Equal([]interface{}{"Trailers", "Commentaries"}, gotArgs)
+	// two unnested arrays do not collide
+	a, b := film.SPECIAL_FEATURES.Unnest().As("a"), film.SPECIAL_FEATURES.Unnest().As("b")
+	gotQuery, _ = Select(a.StringField(), b.StringField()).From(film).CrossJoin(a).CrossJoin(b).ToSQL()
+	is.Equal("SELECT a.a, b.b FROM film CROSS JOIN unnest(film.special_features) AS a"+
+		" CROSS JOIN unnest(film.special_features) AS b", gotQuery)
+	// the default alias is lowercase, so it matches the element column
+	c := film.SPECIAL_FEATURES.Unnest()
+	gotQuery, _ = Select(c.StringField()).From(film).CrossJoin(c).ToSQL()
+	is.Equal("SELECT "+c.Alias+"."+c.Alias+" FROM film CROSS JOIN unnest(film.special_features) AS "+c.Alias, gotQuery)
+	is.Equal(strings.ToLower(c.Alias), c.Alias)
+}
+
+func TestSelectQuery_Aggregates(t *testing.T) {
+	is := is.New(t)
+	p := tables.PAYMENT()
+	q := Select(p.CUSTOMER_ID, CountAll().As("payments"), Sum(p.AMOUNT).Filter(p.STAFF_ID.EqInt(1)).As("staff1_total")).
+		From(p).
+		GroupBy(p.CUSTOMER_ID).
+		Having(CountAll().GtInt(10), Avg(p.AMOUNT).GtFloat64(4.5))
+	wantQuery := "SELECT payment.customer_id, COUNT(*) AS payments," +
+		" SUM(payment.amount) FILTER (WHERE payment.staff_id = $1) AS staff1_total" +
+		" FROM payment GROUP BY payment.customer_id HAVING COUNT(*) > $2 AND AVG(payment.amount) > $3"
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal(wantQuery, gotQuery)
+	is.Equal([]interface{}{1, 10, 4.5}, gotArgs)
+}
+
+func TestSelectQuery_RowLocking(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           SelectQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	r, c := tables.RENTAL().As("r"), tables.CUSTOMER()
+	tests := []TT{
+		{
+			"FOR UPDATE SKIP LOCKED",
+			Select(r.RENTAL_ID).From(r).Where(r.RETURN_DATE.IsNull()).OrderBy(r.RENTAL_ID).Limit(1).ForUpdate().SkipLocked(),
+			"SELECT r.rental_id FROM rental AS r WHERE r.return_date IS NULL ORDER BY r.rental_id LIMIT $1 FOR UPDATE SKIP LOCKED",
+			[]interface{}{uint64(1)},
+		},
+		{
+			"FOR NO KEY UPDATE OF NOWAIT",
+			From(r).Join(c, c.CUSTOMER_ID.Eq(r.CUSTOMER_ID)).Select(r.RENTAL_ID).ForNoKeyUpdate().Of(r).NoWait(),
+			"SELECT r.rental_id FROM rental AS r JOIN customer ON customer.customer_id = r.customer_id FOR NO KEY UPDATE OF r NOWAIT",
+			nil,
+		},
+		{
+			"multiple locking clauses",
+			From(r).Join(c, c.CUSTOMER_ID.Eq(r.CUSTOMER_ID)).Select(r.RENTAL_ID).ForUpdate().Of(r).ForKeyShare().Of(c).ForShare(),
+			"SELECT r.rental_id FROM rental AS r JOIN customer ON customer.customer_id = r.customer_id FOR UPDATE OF r FOR KEY SHARE OF customer FOR SHARE",
+			nil,
+		},
+		{
+			"modifiers without a locking clause do nothing",
+			Select(r.RENTAL_ID).From(r).Of(r).SkipLocked(),
+			"SELECT r.rental_id FROM rental AS r",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestSelectQuery_RowLockingRejected(t *testing.T) {
+	is := is.New(t)
+	r := tables.RENTAL()
+	db := &sql.DB{} // never reached, the query is rejected before it is executed
+	err := SelectDistinct(r.CUSTOMER_ID).From(r).ForUpdate().Fetch(db)
+	is.True(err != nil)
+	is.Equal("row locking clauses are not allowed with DISTINCT", err.Error())
+	_, err = Select(r.CUSTOMER_ID).From(r).GroupBy(r.CUSTOMER_ID).ForShare().Exec(db)
+	is.True(err != nil)
+	is.Equal("row locking clauses are not allowed with GROUP BY", err.Error())
+	// ToSQL leaves the rejection to the caller or to postgres
+	gotQuery, _ := SelectDistinct(r.CUSTOMER_ID).From(r).ForUpdate().ToSQL()
+	is.Equal("SELECT DISTINCT rental.customer_id FROM rental FOR UPDATE", gotQuery)
+}
+
+func TestSelectQuery_RowLockingRejectedNested(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		run         func(db *sql.DB) error
+		wantErr     string
+	}
+	r := tables.RENTAL()
+	grouped := Select(r.CUSTOMER_ID).From(r).GroupBy(r.CUSTOMER_ID).ForUpdate()
+	tests := []TT{
+		{
+			"aggregate in the select list",
+			func(db *sql.DB) error { return Select(CountAll()).From(r).ForUpdate().Fetch(db) },
+			"row locking clauses are not allowed with aggregate functions",
+		},
+		{
+			"aggregate inside an expression",
+			func(db *sql.DB) error {
+				_, err := Select(Max(r.RENTAL_ID).Add(1)).From(r).ForShare().Exec(db)
+				return err
+			},
+			"row locking clauses are not allowed with aggregate functions",
+		},
+		{
+			"window function in the select list",
+			func(db *sql.DB) error {
+				return Select(RowNumber().Over(qx.Window{OrderBy: qx.Fields{r.RENTAL_ID}})).From(r).ForUpdate().Fetch(db)
+			},
+			"row locking clauses are not allowed with window functions",
+		},
+		{
+			"FROM subquery",
+			func(db *sql.DB) error {
+				sub := grouped.As("sub")
+				return Select(sub.Get("customer_id")).From(sub).Fetch(db)
+			},
+			"row locking clauses are not allowed with GROUP BY",
+		},
+		{
+			"CTE",
+			func(db *sql.DB) error {
+				cte := qx.NewCTE("customers", SelectDistinct(r.CUSTOMER_ID).From(r).ForUpdate())
+				_, err := Select(cte.Get("customer_id")).With(cte).From(cte).Exec(db)
+				return err
+			},
+			"row locking clauses are not allowed with DISTINCT",
+		},
+		{
+			"WHERE subquery of an UPDATE",
+			func(db *sql.DB) error {
+				_, err := Update(r).Set(r.STAFF_ID.SetInt(1)).Where(r.CUSTOMER_ID.In(grouped)).Exec(db)
+				return err
+			},
+			"row locking clauses are not allowed with GROUP BY",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			err := tt.run(&sql.DB{}) // never reached, the query is rejected before it is executed
+			is.True(err != nil)
+			is.Equal(tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestUpdateDeleteQuery_JoinUsing(t *testing.T) {
+	is := is.New(t)
+	cust, addr, city := tables.CUSTOMER(), tables.ADDRESS(), tables.CITY()
+	gotQuery, gotArgs := Update(cust).Set(cust.STORE_ID.SetInt(2)).From(addr).JoinUsing(city, addr.CITY_ID).
+		Where(cust.ADDRESS_ID.Eq(addr.ADDRESS_ID), city.CITY.EqString("Sasebo")).ToSQL()
+	is.Equal("UPDATE customer SET store_id = $1 FROM address JOIN city USING (city_id)"+
+		" WHERE customer.address_id = address.address_id AND city.city = $2", gotQuery)
+	is.Equal([]interface{}{2, "Sasebo"}, gotArgs)
+	gotQuery, _ = DeleteFrom(cust).Using(addr).NaturalJoin(city).Where(cust.ADDRESS_ID.Eq(addr.ADDRESS_ID)).ToSQL()
+	is.Equal("DELETE FROM customer USING address NATURAL JOIN city WHERE customer.address_id = address.address_id", gotQuery)
+}
+
+func TestSelectQuery_GroupingSets(t *testing.T) {
+	is := is.New(t)
+	p := tables.PAYMENT()
+	q := Select(p.STAFF_ID, p.CUSTOMER_ID, Sum(p.AMOUNT).As("total"), Grouping(p.STAFF_ID, p.CUSTOMER_ID).As("grp")).
+		From(p).
+		GroupBy(Rollup(p.STAFF_ID, p.CUSTOMER_ID)).
+		OrderBy(p.STAFF_ID, p.CUSTOMER_ID)
+	wantQuery := "SELECT payment.staff_id, payment.customer_id, SUM(payment.amount) AS total," +
+		" GROUPING(payment.staff_id, payment.customer_id) AS grp FROM payment" +
+		" GROUP BY ROLLUP (payment.staff_id, payment.customer_id) ORDER BY payment.staff_id, payment.customer_id"
+	gotQuery, gotArgs := q.ToSQL()
+	is.Equal(wantQuery, gotQuery)
+	is.Equal(0, len(gotArgs))
+	gotQuery, _ = Select(p.STAFF_ID).From(p).GroupBy(p.STAFF_ID, Cube(p.CUSTOMER_ID), GroupingSets(Fields{p.PAYMENT_DATE}, Fields{})).ToSQL()
+	is.Equal("SELECT payment.staff_id FROM payment GROUP BY payment.staff_id, CUBE (payment.customer_id),"+
+		" GROUPING SETS ((payment.payment_date), ())", gotQuery)
+}
+
+func TestValuesTable(t *testing.T) {
+	is := is.New(t)
+	f := tables.FILM()
+	v := Values(
+		[]interface{}{Cast(Int(1), "INT"), "Academy Dinosaur"},
+		[]interface{}{2, "Ace Goldfinger"},
+	).As("v", "id", "title")
+	gotQuery, gotArgs := Select(v.Number("id"), v.String("title")).From(v).ToSQL()
+	is.Equal("SELECT v.id, v.title FROM (VALUES (CAST($1 AS INT), $2), ($3, $4)) AS v (id, title)", gotQuery)
+	is.Equal([]interface{}{1, "Academy Dinosaur", 2, "Ace Goldfinger"}, gotArgs)
+	gotQuery, _ = Select(f.FILM_ID).From(f).Join(v, v.Number("id").Eq(f.FILM_ID)).ToSQL()
+	is.Equal("SELECT film.film_id FROM film JOIN (VALUES (CAST($1 AS INT), $2), ($3, $4)) AS v (id, title) ON v.id = film.film_id", gotQuery)
+	gotQuery, gotArgs = Update(f).Set(f.TITLE.Set(v.String("title"))).From(v).Where(f.FILM_ID.Eq(v.Number("id"))).ToSQL()
+	is.Equal("UPDATE film SET title = v.title FROM (VALUES (CAST($1 AS INT), $2), ($3, $4)) AS v (id, title) WHERE film.film_id = v.id", gotQuery)
+	is.Equal(4, len(gotArgs))
+	// the ValuesTable is aliased like any other Table
+	is.Equal("v", v.GetAlias())
+	is.Equal("", v.GetName())
+	gotQuery, _ = DeleteFrom(f).Using(v).Where(f.FILM_ID.Eq(v.Number("id"))).ToSQL()
+	is.Equal("DELETE FROM film USING (VALUES (CAST($1 AS INT), $2), ($3, $4)) AS v (id, title) WHERE film.film_id = v.id", gotQuery)
+	w := Values([]interface{}{1}).As("w")
+	gotQuery, _ = Select(w.Number("column1")).From(w).ToSQL()
+	is.Equal("SELECT w.column1 FROM (VALUES ($1)) AS w", gotQuery)
+}
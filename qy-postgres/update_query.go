@@ -15,8 +15,9 @@ import (
 )
 
 type UpdateQuery struct {
-	Nested bool
-	Alias  string
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
 	// WITH
 	CTEs qx.CTEs
 	// UPDATE
@@ -61,7 +62,7 @@ func (q UpdateQuery) ToSQL() (string, []interface{}) {
 				buf.WriteString(" ")
 			}
 			if q.UpdateTable.GetAlias() != "" {
-				buf.WriteString("UPDATE " + updateQuery + " AS " + q.UpdateTable.GetAlias())
+				buf.WriteString("UPDATE " + updateQuery + " AS " + qx.TableAlias(q.UpdateTable))
 			} else {
 				buf.WriteString("UPDATE " + updateQuery)
 			}
@@ -80,7 +81,7 @@ func (q UpdateQuery) ToSQL() (string, []interface{}) {
 				buf.WriteString(" ")
 			}
 			if q.FromTable.GetAlias() != "" {
-				buf.WriteString("FROM " + fromQuery + " AS " + q.FromTable.GetAlias())
+				buf.WriteString("FROM " + fromQuery + " AS " + qx.TableAlias(q.FromTable))
 			} else {
 				buf.WriteString("FROM " + fromQuery)
 			}
@@ -96,15 +97,18 @@ func (q UpdateQuery) ToSQL() (string, []interface{}) {
 	q.ReturningFields.WriteSQLWithAlias(buf, &args, "RETURNING ", "", nil)
 	query := buf.String()
 	if !q.Nested {
-		query = qx.MySQLToPostgresPlaceholders(query)
+		if q.Dialect == nil {
+			q.Dialect = qx.Postgres
+		}
+		query = qx.Rebind(q.Dialect, query)
 		if q.Log != nil {
 			var logOutput string
 			switch {
 			case LStats&q.LogFlag != 0:
 				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
-					"\n----[ with bind values ]----\n" + qx.PostgresInterpolateSQL(query, args...)
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
 			case LInterpolate&q.LogFlag != 0:
-				logOutput = "Executing query: " + qx.PostgresInterpolateSQL(query, args...)
+				logOutput = "Executing query: " + q.Dialect.InterpolateSQL(query, args...)
 			default:
 				logOutput = "Executing query: " + query + " " + fmt.Sprint(args)
 			}
@@ -119,6 +123,13 @@ func (q UpdateQuery) ToSQL() (string, []interface{}) {
 	return query, args
 }
 
+// ToSQLDialect marshals the UpdateQuery into an SQL query for the given dialect.
+func (q UpdateQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
 func (q UpdateQuery) GetAlias() string {
 	return q.Alias
 }
@@ -212,6 +223,43 @@ func (q UpdateQuery) CrossJoin(tbl qx.Table) UpdateQuery {
 	return q
 }
 
+func (q UpdateQuery) JoinLateral(tbl qx.Table, preds ...qx.Predicate) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeDefault,
+		Lateral:      true,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) LeftJoinLateral(tbl qx.Table, preds ...qx.Predicate) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeLeft,
+		Lateral:      true,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) JoinUsing(tbl qx.Table, fields ...qx.Field) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:    qx.JoinTypeDefault,
+		Table:       tbl,
+		UsingFields: fields,
+	})
+	return q
+}
+
+func (q UpdateQuery) NaturalJoin(tbl qx.Table) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType: qx.JoinTypeNatural,
+		Table:    tbl,
+	})
+	return q
+}
+
 func (q UpdateQuery) Where(preds ...qx.Predicate) UpdateQuery {
 	q.WherePredicates.Predicates = append(q.WherePredicates.Predicates, preds...)
 	return q
@@ -343,9 +391,17 @@ func (q UpdateQuery) Exec(db qx.DB) (sql.Result, error) {
 	return q.ExecContext(nil, db)
 }
 
-func (q UpdateQuery) ExecContext(ctx context.Context, db qx.DB) (sql.Result, error) {
-	var res sql.Result
-	var err error
+func (q UpdateQuery) ExecContext(ctx context.Context, db qx.DB) (res sql.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
 	if db == nil {
 		if q.DB == nil {
 			return res, errors.New("DB cannot be nil")
@@ -0,0 +1,427 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+// CompoundQuery represents several queries combined with a set operator i.e.
+// 'query1 UNION query2 UNION query3 ...'. The ORDER BY, LIMIT and OFFSET of
+// a CompoundQuery apply to the combined result.
+type CompoundQuery struct {
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
+	// UNION / INTERSECT / EXCEPT
+	Operator qx.VariadicQueryOperator
+	Queries  []qx.Query
+	// ORDER BY
+	OrderByFields qx.Fields
+	// LIMIT
+	LimitValue *uint64
+	// OFFSET
+	OffsetValue *uint64
+	// DB
+	DB          qx.DB
+	Mapper      func(Row)
+	Accumulator func()
+	// Logging
+	Log     qx.Logger
+	LogFlag int
+	LogSkip int
+}
+
+// ToSQL marshals the CompoundQuery into an SQL query and args.
+func (q CompoundQuery) ToSQL() (string, []interface{}) {
+	var buf = &strings.Builder{}
+	var args []interface{}
+	// branches
+	branches := q.branches()
+	branches.WriteSQL(buf, &args, q.Operator, "SELECT * FROM (", ")")
+	// ORDER BY
+	q.OrderByFields.WriteSQL(buf, &args, "ORDER BY ", "", branches.TableQualifiers())
+	// LIMIT
+	if q.LimitValue != nil {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("LIMIT ?")
+		args = append(args, *q.LimitValue)
+	}
+	// OFFSET
+	if q.OffsetValue != nil {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("OFFSET ?")
+		args = append(args, *q.OffsetValue)
+	}
+	query := buf.String()
+	if !q.Nested {
+		if q.Dialect == nil {
+			q.Dialect = qx.SQLite
+		}
+		query = qx.Rebind(q.Dialect, query)
+		if q.Log != nil {
+			var logOutput string
+			switch {
+			case LStats&q.LogFlag != 0:
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
+			case LInterpolate&q.LogFlag != 0:
+				logOutput = q.Dialect.InterpolateSQL(query, args...)
+			default:
+				logOutput = query + " " + fmt.Sprint(args)
+			}
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logOutput)
+			default:
+				q.Log.Output(q.LogSkip+1, logOutput)
+			}
+		}
+	}
+	return query, args
+}
+
+// branches returns the branches of the CompoundQuery. A branch that has its
+// own WITH, ORDER BY, LIMIT or OFFSET (or is itself a CompoundQuery) is
+// wrapped in 'SELECT * FROM (branch)' so that those clauses only apply to the
+// branch, because sqlite does not allow compound query branches to be
+// parenthesized.
+func (q CompoundQuery) branches() qx.CompoundBranches {
+	branches := make(qx.CompoundBranches, len(q.Queries))
+	for i := range q.Queries {
+		branches[i].Query = q.Queries[i]
+		switch query := q.Queries[i].(type) {
+		case SelectQuery:
+			tables := []qx.Table{query.FromTable}
+			for j := range query.JoinGroups {
+				tables = append(tables, query.JoinGroups[j].Table)
+			}
+			branches[i] = qx.CompoundBranch{
+				Query:        query,
+				Parenthesize: len(query.CTEs) > 0 || len(query.OrderByFields) > 0 || query.LimitValue != nil || query.OffsetValue != nil,
+				Tables:       tables,
+				NoFields:     len(query.SelectFields) == 0,
+			}
+		case CompoundQuery:
+			branches[i].Parenthesize = true
+			for _, branch := range query.branches() {
+				branches[i].Locked = branches[i].Locked || branch.Locked
+			}
+		}
+	}
+	return branches
+}
+
+// ToSQLDialect marshals the CompoundQuery into an SQL query for the given
+// dialect.
+func (q CompoundQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
+// compound combines the SelectQuery with the queries using the operator. The
+// SelectQuery's DB, Mapper and logging settings are carried over to the
+// CompoundQuery.
+func (q SelectQuery) compound(operator qx.VariadicQueryOperator, queries []qx.Query) CompoundQuery {
+	return CompoundQuery{
+		Alias:       qx.RandomString(8),
+		Dialect:     q.Dialect,
+		Operator:    operator,
+		Queries:     append([]qx.Query{q}, queries...),
+		DB:          q.DB,
+		Mapper:      q.Mapper,
+		Accumulator: q.Accumulator,
+		Log:         q.Log,
+		LogFlag:     q.LogFlag,
+		LogSkip:     q.LogSkip,
+	}
+}
+
+// Union returns a new CompoundQuery representing 'q UNION queries...'.
+func (q SelectQuery) Union(queries ...qx.Query) CompoundQuery {
+	return q.compound(qx.QueryUnion, queries)
+}
+
+// UnionAll returns a new CompoundQuery representing 'q UNION ALL queries...'.
+func (q SelectQuery) UnionAll(queries ...qx.Query) CompoundQuery {
+	return q.compound(qx.QueryUnionAll, queries)
+}
+
+// Intersect returns a new CompoundQuery representing 'q INTERSECT queries...'.
+func (q SelectQuery) Intersect(queries ...qx.Query) CompoundQuery {
+	return q.compound(qx.QueryIntersect, queries)
+}
+
+// Except returns a new CompoundQuery representing 'q EXCEPT queries...'.
+func (q SelectQuery) Except(queries ...qx.Query) CompoundQuery {
+	return q.compound(qx.QueryExcept, queries)
+}
+
+// compound combines the CompoundQuery with the queries using the operator. If
+// the operator is the same and the CompoundQuery has no ORDER BY, LIMIT or
+// OFFSET the queries are simply added as branches, otherwise the
+// CompoundQuery becomes the (parenthesized) first branch of a new
+// CompoundQuery.
+func (q CompoundQuery) compound(operator qx.VariadicQueryOperator, queries []qx.Query) CompoundQuery {
+	if q.Operator == "" {
+		q.Operator = qx.QueryUnion
+	}
+	if q.Operator == operator && len(q.OrderByFields) == 0 && q.LimitValue == nil && q.OffsetValue == nil {
+		q.Queries = append(q.Queries[:len(q.Queries):len(q.Queries)], queries...)
+		return q
+	}
+	outer := q
+	outer.Operator = operator
+	outer.Queries = append([]qx.Query{q}, queries...)
+	outer.OrderByFields = nil
+	outer.LimitValue = nil
+	outer.OffsetValue = nil
+	return outer
+}
+
+// Union returns a new CompoundQuery representing 'q UNION queries...'.
+func (q CompoundQuery) Union(queries ...qx.Query) CompoundQuery {
+	return q.compound(qx.QueryUnion, queries)
+}
+
+// UnionAll returns a new CompoundQuery representing 'q UNION ALL queries...'.
+func (q CompoundQuery) UnionAll(queries ...qx.Query) CompoundQuery {
+	return q.compound(qx.QueryUnionAll, queries)
+}
+
+// Intersect returns a new CompoundQuery representing 'q INTERSECT queries...'.
+func (q CompoundQuery) Intersect(queries ...qx.Query) CompoundQuery {
+	return q.compound(qx.QueryIntersect, queries)
+}
+
+// Except returns a new CompoundQuery representing 'q EXCEPT queries...'.
+func (q CompoundQuery) Except(queries ...qx.Query) CompoundQuery {
+	return q.compound(qx.QueryExcept, queries)
+}
+
+func (q CompoundQuery) OrderBy(fields ...qx.Field) CompoundQuery {
+	q.OrderByFields = append(q.OrderByFields, fields...)
+	return q
+}
+
+func (q CompoundQuery) Limit(limit int) CompoundQuery {
+	if limit < 0 {
+		limit = -limit
+	}
+	num := uint64(limit)
+	q.LimitValue = &num
+	return q
+}
+
+func (q CompoundQuery) Offset(offset int) CompoundQuery {
+	if offset < 0 {
+		offset = -offset
+	}
+	num := uint64(offset)
+	q.OffsetValue = &num
+	return q
+}
+
+func (q CompoundQuery) Selectx(mapper func(Row), accumulator func()) CompoundQuery {
+	q.Mapper = mapper
+	q.Accumulator = accumulator
+	return q
+}
+
+func (q CompoundQuery) SelectRowx(mapper func(Row)) CompoundQuery {
+	q.Mapper = mapper
+	return q
+}
+
+func (q CompoundQuery) Fetch(db qx.DB) error {
+	q.LogSkip += 1
+	return q.FetchContext(nil, db)
+}
+
+// FetchContext runs the CompoundQuery and maps every row of the combined
+// result with the Mapper. If the first branch is a SelectQuery without any
+// selected fields it will select the fields collected by the Mapper, every
+// other branch must select its own fields.
+func (q CompoundQuery) FetchContext(ctx context.Context, db qx.DB) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	logBuf := &strings.Builder{}
+	var rowcount int
+	defer func() func() {
+		var logskip int
+		switch q.Log.(type) {
+		case *log.Logger:
+			logskip = q.LogSkip + 2
+		default:
+			logskip = q.LogSkip + 1
+		}
+		start := time.Now()
+		return func() {
+			elapsed := time.Since(start)
+			if LResults&q.LogFlag != 0 && q.Log != nil && rowcount > 5 {
+				logBuf.WriteString("\n...")
+			}
+			if LStats&q.LogFlag != 0 && q.Log != nil {
+				logBuf.WriteString("\n(Fetched " + strconv.Itoa(rowcount) + " rows in " + elapsed.String() + ")")
+			}
+			if logBuf.Len() > 0 && q.Log != nil {
+				q.Log.Output(logskip, logBuf.String())
+			}
+		}
+	}()()
+	if db == nil {
+		if q.DB == nil {
+			return errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	r := &qx.QxRow{}
+	if q.Mapper != nil {
+		q.Mapper(r) // call the mapper once on the *Row to get all the selected that the user is interested in
+		fields := r.Fields
+		if len(fields) == 0 {
+			fields = append(fields, Fieldf("1"))
+		}
+		// then, transfer the selected collected by *Row to the first branch if it has not selected anything
+		if len(q.Queries) > 0 {
+			if branch, ok := q.Queries[0].(SelectQuery); ok && len(branch.SelectFields) == 0 {
+				queries := make([]qx.Query, len(q.Queries))
+				copy(queries, q.Queries)
+				branch.SelectFields = fields
+				queries[0] = branch
+				q.Queries = queries
+			}
+		}
+	}
+	if err = q.branches().Check(); err != nil {
+		return err
+	}
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		r.Rows, err = db.Query(query, args...)
+	} else {
+		r.Rows, err = db.QueryContext(ctx, query, args...)
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Rows.Close()
+	if len(r.Dest) == 0 {
+		// If there's nothing to scan into, return early
+		return nil
+	}
+	for r.Rows.Next() {
+		rowcount++
+		err = r.Rows.Scan(r.Dest...)
+		if err != nil {
+			buf := &strings.Builder{}
+			for i := range r.Dest {
+				query, args := r.Fields[i].ToSQLExclude(nil)
+				buf.WriteString("\n" +
+					strconv.Itoa(i) + ") " +
+					qx.MySQLInterpolateSQL(query, args...) + " => " +
+					reflect.TypeOf(r.Dest[i]).String())
+			}
+			return fmt.Errorf("Please check if your mapper function is correct:%s\n%w", buf.String(), err)
+		}
+		if LResults&q.LogFlag != 0 && q.Log != nil && rowcount <= 5 {
+			logBuf.WriteString("\n----[ Row " + strconv.Itoa(rowcount) + " ]----")
+			for i := range r.Dest {
+				q, a := r.Fields[i].ToSQLExclude(nil)
+				logBuf.WriteString("\n" + qx.MySQLInterpolateSQL(q, a...) + ": " + qx.ArgToStringV2(r.Dest[i]))
+			}
+		}
+		r.Index = 0 // index must always be reset back to 0 before mapper is called
+		q.Mapper(r)
+		if q.Accumulator == nil {
+			break
+		}
+		q.Accumulator()
+	}
+	if rowcount == 0 && q.Accumulator == nil {
+		return sql.ErrNoRows
+	}
+	if e := r.Rows.Close(); e != nil {
+		return e
+	}
+	return r.Rows.Err()
+}
+
+func (q CompoundQuery) Exec(db qx.DB) (sql.Result, error) {
+	q.LogSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+func (q CompoundQuery) ExecContext(ctx context.Context, db qx.DB) (res sql.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	if db == nil {
+		if q.DB == nil {
+			return res, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	if err = q.branches().Check(); err != nil {
+		return res, err
+	}
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		res, err = db.Exec(query, args...)
+	} else {
+		res, err = db.ExecContext(ctx, query, args...)
+	}
+	return res, err
+}
+
+func (q CompoundQuery) As(alias string) CompoundQuery {
+	q.Alias = alias
+	return q
+}
+
+func (q CompoundQuery) Get(fieldName string) qx.CustomField {
+	return Fieldf(q.Alias + "." + fieldName)
+}
+
+func (q CompoundQuery) GetAlias() string {
+	return q.Alias
+}
+
+func (q CompoundQuery) GetName() string {
+	return ""
+}
+
+func (q CompoundQuery) NestThis() qx.Query {
+	q.Nested = true
+	return q
+}
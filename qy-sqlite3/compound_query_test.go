@@ -0,0 +1,20 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCompoundQuery_ToSQL(t *testing.T) {
+	is := is.New(t)
+	cust := CUSTOMER()
+	store := func(id int) SelectQuery {
+		return Select(cust.CUSTOMER_ID).From(cust).Where(cust.STORE_ID.EqInt(id))
+	}
+	gotQuery, gotArgs := store(1).Limit(5).UnionAll(store(2)).OrderBy(cust.CUSTOMER_ID.Desc()).Limit(10).ToSQL()
+	is.Equal("SELECT * FROM (SELECT customer.customer_id FROM customer WHERE customer.store_id = ? LIMIT ?)"+
+		" UNION ALL SELECT customer.customer_id FROM customer WHERE customer.store_id = ?"+
+		" ORDER BY customer_id DESC LIMIT ?", gotQuery)
+	is.Equal([]interface{}{1, uint64(5), 2, uint64(10)}, gotArgs)
+}
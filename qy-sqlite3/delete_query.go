@@ -0,0 +1,322 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+type DeleteQuery struct {
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
+	// WITH
+	CTEs qx.CTEs
+	// DELETE FROM
+	FromTable qx.BaseTable
+	// WHERE
+	WherePredicates qx.VariadicPredicate
+	// RETURNING
+	ReturningFields qx.Fields
+	// ORDER BY
+	OrderByFields qx.Fields
+	// LIMIT
+	LimitValue *uint64
+	// DB
+	DB          qx.DB
+	Mapper      func(Row)
+	Accumulator func()
+	// Logging
+	Log     qx.Logger
+	LogFlag int
+	LogSkip int
+}
+
+func (q DeleteQuery) ToSQL() (string, []interface{}) {
+	var buf = &strings.Builder{}
+	var args []interface{}
+	var excludeTableQualifiers []string
+	// WITH
+	q.CTEs.WriteSQL(buf, &args)
+	{ // DELETE FROM
+		deleteQuery, deleteArgs := "", []interface{}{}
+		if q.FromTable != nil {
+			deleteQuery, deleteArgs = q.FromTable.ToSQL()
+			if q.FromTable.GetAlias() != "" {
+				excludeTableQualifiers = append(excludeTableQualifiers, q.FromTable.GetAlias())
+			} else if q.FromTable.GetName() != "" {
+				excludeTableQualifiers = append(excludeTableQualifiers, q.FromTable.GetName())
+			}
+		}
+		if deleteQuery != "" {
+			if buf.Len() > 0 {
+				buf.WriteString(" ")
+			}
+			if q.FromTable.GetAlias() != "" {
+				buf.WriteString("DELETE FROM " + deleteQuery + " AS " + qx.TableAlias(q.FromTable))
+			} else {
+				buf.WriteString("DELETE FROM " + deleteQuery)
+			}
+			args = append(args, deleteArgs...)
+		}
+	}
+	// WHERE
+	q.WherePredicates.Toplevel = true
+	q.WherePredicates.WriteSQL(buf, &args, "WHERE ", "", nil)
+	// RETURNING
+	q.ReturningFields.WriteSQLWithAlias(buf, &args, "RETURNING ", "", nil)
+	// ORDER BY
+	q.OrderByFields.WriteSQL(buf, &args, "ORDER BY ", "", nil)
+	// LIMIT
+	if q.LimitValue != nil {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("LIMIT ?")
+		args = append(args, *q.LimitValue)
+	}
+	query := buf.String()
+	if !q.Nested {
+		if q.Dialect == nil {
+			q.Dialect = qx.SQLite
+		}
+		query = qx.Rebind(q.Dialect, query)
+		if q.Log != nil {
+			var logOutput string
+			switch {
+			case LStats&q.LogFlag != 0:
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
+			case LInterpolate&q.LogFlag != 0:
+				logOutput = "Executing query: " + q.Dialect.InterpolateSQL(query, args...)
+			default:
+				logOutput = "Executing query: " + query + " " + fmt.Sprint(args)
+			}
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logOutput)
+			default:
+				q.Log.Output(q.LogSkip+1, logOutput)
+			}
+		}
+	}
+	return query, args
+}
+
+// ToSQLDialect marshals the DeleteQuery into an SQL query for the given dialect.
+func (q DeleteQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
+func (q DeleteQuery) GetAlias() string {
+	return q.Alias
+}
+
+func (q DeleteQuery) GetName() string {
+	return ""
+}
+
+func (q DeleteQuery) NestThis() qx.Query {
+	q.Nested = true
+	return q
+}
+
+func (q DeleteQuery) As(alias string) DeleteQuery {
+	q.Alias = alias
+	return q
+}
+
+func DeleteFrom(table qx.BaseTable) DeleteQuery {
+	return DeleteQuery{
+		FromTable: table,
+		Alias:     qx.RandomString(8),
+	}
+}
+
+func (q DeleteQuery) With(cteList ...qx.CTE) DeleteQuery {
+	q.CTEs = append(q.CTEs, cteList...)
+	return q
+}
+
+func (q DeleteQuery) DeleteFrom(tbl qx.BaseTable) DeleteQuery {
+	q.FromTable = tbl
+	return q
+}
+
+func (q DeleteQuery) Where(predicates ...qx.Predicate) DeleteQuery {
+	q.WherePredicates.Predicates = append(q.WherePredicates.Predicates, predicates...)
+	return q
+}
+
+// OrderBy is only available if SQLite was compiled with
+// SQLITE_ENABLE_UPDATE_DELETE_LIMIT.
+func (q DeleteQuery) OrderBy(fields ...qx.Field) DeleteQuery {
+	q.OrderByFields = append(q.OrderByFields, fields...)
+	return q
+}
+
+// Limit is only available if SQLite was compiled with
+// SQLITE_ENABLE_UPDATE_DELETE_LIMIT.
+func (q DeleteQuery) Limit(limit int) DeleteQuery {
+	if limit < 0 {
+		limit = -limit
+	}
+	num := uint64(limit)
+	q.LimitValue = &num
+	return q
+}
+
+func (q DeleteQuery) Returning(fields ...qx.Field) DeleteQuery {
+	q.ReturningFields = append(q.ReturningFields, fields...)
+	return q
+}
+
+func (q DeleteQuery) ReturningOne() DeleteQuery {
+	q.ReturningFields = qx.Fields{qx.FieldLiteral("1")}
+	return q
+}
+
+func (q DeleteQuery) Returningx(mapper func(Row), accumulator func()) DeleteQuery {
+	q.Mapper = mapper
+	q.Accumulator = accumulator
+	return q
+}
+
+func (q DeleteQuery) ReturningRowx(mapper func(Row)) DeleteQuery {
+	q.Mapper = mapper
+	return q
+}
+
+func (q DeleteQuery) Fetch(db qx.DB) (err error) {
+	q.LogSkip += 1
+	return q.FetchContext(nil, db)
+}
+
+func (q DeleteQuery) FetchContext(ctx context.Context, db qx.DB) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	logBuf := &strings.Builder{}
+	var rowcount int
+	defer func() func() {
+		var logskip int
+		switch q.Log.(type) {
+		case *log.Logger:
+			logskip = q.LogSkip + 2
+		default:
+			logskip = q.LogSkip + 1
+		}
+		start := time.Now()
+		return func() {
+			elapsed := time.Since(start)
+			if LResults&q.LogFlag != 0 && q.Log != nil && rowcount > 5 {
+				logBuf.WriteString("\n...")
+			}
+			if LStats&q.LogFlag != 0 && q.Log != nil {
+				logBuf.WriteString("\n(Fetched " + strconv.Itoa(rowcount) + " rows in " + elapsed.String() + ")")
+			}
+			if logBuf.Len() > 0 && q.Log != nil {
+				q.Log.Output(logskip, logBuf.String())
+			}
+		}
+	}()()
+	if db == nil {
+		if q.DB == nil {
+			return errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	r := &qx.QxRow{}
+	if q.Mapper != nil {
+		q.Mapper(r) // call the mapper once on the *Row to get all the selected that the user is interested in
+	}
+	q.ReturningFields = r.Fields // then, transfer the selected collected by *Row to the DeleteQuery
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		r.Rows, err = db.Query(query, args...)
+	} else {
+		r.Rows, err = db.QueryContext(ctx, query, args...)
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Rows.Close()
+	if len(r.Dest) == 0 {
+		// If there's nothing to scan into, return early
+		return nil
+	}
+	for r.Rows.Next() {
+		rowcount++
+		err = r.Rows.Scan(r.Dest...)
+		if err != nil {
+			buf := &strings.Builder{}
+			for i := range r.Dest {
+				query, args := r.Fields[i].ToSQLExclude(nil)
+				buf.WriteString("\n" +
+					strconv.Itoa(i) + ") " +
+					qx.MySQLInterpolateSQL(query, args...) + " => " +
+					reflect.TypeOf(r.Dest[i]).String())
+			}
+			return fmt.Errorf("Please check if your mapper function is correct:%s\n%w", buf.String(), err)
+		}
+		if LResults&q.LogFlag != 0 && q.Log != nil && rowcount <= 5 {
+			logBuf.WriteString("\n----[ Row " + strconv.Itoa(rowcount) + " ]----")
+			for i := range r.Dest {
+				q, a := r.Fields[i].ToSQLExclude(nil)
+				logBuf.WriteString("\n" + qx.MySQLInterpolateSQL(q, a...) + ": " + qx.ArgToStringV2(r.Dest[i]))
+			}
+		}
+		r.Index = 0 // index must always be reset back to 0 before mapper is called
+		q.Mapper(r)
+		if q.Accumulator == nil {
+			break
+		}
+		q.Accumulator()
+	}
+	if rowcount == 0 && q.Accumulator == nil {
+		return sql.ErrNoRows
+	}
+	return r.Rows.Err()
+}
+
+func (q DeleteQuery) Exec(db qx.DB) (sql.Result, error) {
+	q.LogSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+func (q DeleteQuery) ExecContext(ctx context.Context, db qx.DB) (sql.Result, error) {
+	var res sql.Result
+	var err error
+	if db == nil {
+		if q.DB == nil {
+			return res, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		res, err = db.Exec(query, args...)
+	} else {
+		res, err = db.ExecContext(ctx, query, args...)
+	}
+	return res, err
+}
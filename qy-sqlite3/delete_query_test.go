@@ -0,0 +1,47 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDeleteQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           DeleteQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "delete with returning"
+			actor := ACTOR().As("a")
+			q := DeleteFrom(actor).
+				Where(actor.FIRST_NAME.EqString("PENELOPE")).
+				Returning(actor.ACTOR_ID)
+			wantQuery := "DELETE FROM actor AS a WHERE a.first_name = ? RETURNING a.actor_id"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{"PENELOPE"}}
+		}(),
+		func() TT {
+			DESCRIPTION := "delete with order by and limit"
+			actor := ACTOR()
+			q := DeleteFrom(actor).
+				Where(actor.FIRST_NAME.EqString("PENELOPE")).
+				OrderBy(actor.LAST_UPDATE.Desc()).
+				Limit(5)
+			wantQuery := "DELETE FROM actor WHERE actor.first_name = ? ORDER BY actor.last_update DESC LIMIT ?"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{"PENELOPE", uint64(5)}}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
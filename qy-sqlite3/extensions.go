@@ -0,0 +1,54 @@
+package qy
+
+import (
+	"errors"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+func Exists(query qx.Query, db qx.DB) (exists bool, err error) {
+	var dbV2 qx.DB
+	var logger qx.Logger
+	var dialect qx.Dialect
+	switch q := query.(type) {
+	case SelectQuery:
+		q.SelectFields = []qx.Field{Fieldf("1")}
+		dbV2 = q.DB
+		logger = q.Log
+		dialect = q.Dialect
+		query = q
+	default:
+		return exists, errors.New("query is not a SelectQuery")
+	}
+	if db == nil && dbV2 != nil {
+		db = dbV2
+	}
+	if db == nil {
+		return exists, errors.New("DB is not set")
+	}
+	if dialect == nil {
+		dialect = qx.SQLite
+	}
+	queryString, args := query.ToSQL()
+	queryString = "SELECT EXISTS(" + queryString + ")"
+	rows, err := db.Query(queryString, args...)
+	if logger != nil {
+		interpolatedQuery := dialect.InterpolateSQL(queryString, args...)
+		logger.Output(1, interpolatedQuery)
+	}
+	if err != nil {
+		return exists, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		err = rows.Scan(&exists)
+		if err != nil {
+			return exists, err
+		}
+		break
+	}
+	if err = rows.Close(); err != nil {
+		return exists, err
+	}
+	return exists, rows.Err()
+}
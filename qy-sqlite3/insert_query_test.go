@@ -0,0 +1,88 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestInsertQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           InsertQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "basic insert with returning"
+			actor := ACTOR()
+			q := InsertInto(actor).
+				Columns(actor.FIRST_NAME, actor.LAST_NAME).
+				Values("PENELOPE", "GUINESS").
+				Values("NICK", "WAHLBERG").
+				Returning(actor.ACTOR_ID)
+			wantQuery := "INSERT INTO actor (first_name, last_name) VALUES (?, ?), (?, ?) RETURNING actor.actor_id"
+			wantArgs := []interface{}{"PENELOPE", "GUINESS", "NICK", "WAHLBERG"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "INSERT OR REPLACE"
+			actor := ACTOR()
+			q := InsertOrReplaceInto(actor).
+				Columns(actor.ACTOR_ID, actor.FIRST_NAME).
+				Values(1, "PENELOPE")
+			wantQuery := "INSERT OR REPLACE INTO actor (actor_id, first_name) VALUES (?, ?)"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{1, "PENELOPE"}}
+		}(),
+		func() TT {
+			DESCRIPTION := "INSERT OR IGNORE"
+			actor := ACTOR()
+			q := InsertOrIgnoreInto(actor).
+				InsertRow(actor.ACTOR_ID.Set(1), actor.FIRST_NAME.Set("PENELOPE"))
+			wantQuery := "INSERT OR IGNORE INTO actor (actor_id, first_name) VALUES (?, ?)"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{1, "PENELOPE"}}
+		}(),
+		func() TT {
+			DESCRIPTION := "upsert"
+			actor := ACTOR().As("a")
+			q := InsertInto(actor).
+				Columns(actor.ACTOR_ID, actor.FIRST_NAME, actor.LAST_NAME).
+				Values(1, "PENELOPE", "GUINESS").
+				OnConflict(actor.ACTOR_ID).
+				DoUpdateSet(
+					actor.FIRST_NAME.Set(Excluded(actor.FIRST_NAME)),
+					actor.LAST_NAME.Set(Excluded(actor.LAST_NAME)),
+				).
+				Where(actor.LAST_NAME.NeString("GUINESS"))
+			wantQuery := "INSERT INTO actor AS a (actor_id, first_name, last_name) VALUES (?, ?, ?)" +
+				" ON CONFLICT (actor_id) DO UPDATE SET first_name = EXCLUDED.first_name, last_name = EXCLUDED.last_name" +
+				" WHERE a.last_name <> ?"
+			wantArgs := []interface{}{1, "PENELOPE", "GUINESS", "GUINESS"}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "INSERT ... SELECT ... ON CONFLICT DO NOTHING"
+			actor, cust := ACTOR(), CUSTOMER()
+			q := InsertInto(actor).
+				Columns(actor.FIRST_NAME, actor.LAST_NAME).
+				Select(Select(cust.FIRST_NAME, cust.LAST_NAME).From(cust)).
+				OnConflict().
+				DoNothing()
+			wantQuery := "INSERT INTO actor (first_name, last_name)" +
+				" SELECT customer.first_name, customer.last_name FROM customer WHERE TRUE" +
+				" ON CONFLICT DO NOTHING"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
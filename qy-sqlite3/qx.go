@@ -0,0 +1,171 @@
+package qy
+
+import (
+	"time"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+func Bytes(b []byte) qx.BinaryField      { return qx.Bytes(b) }
+func Bool(b bool) qx.BooleanField        { return qx.Bool(b) }
+func Int(num int) qx.NumberField         { return qx.Int(num) }
+func Int64(num int64) qx.NumberField     { return qx.Int64(num) }
+func Float64(num float64) qx.NumberField { return qx.Float64(num) }
+func String(s string) qx.StringField     { return qx.String(s) }
+func Time(t time.Time) qx.TimeField      { return qx.Time(t) }
+func Now() qx.TimeField                  { return qx.Now() }
+func CurrentDate() qx.TimeField          { return qx.CurrentDate() }
+
+type Table = qx.Table
+type Query = qx.Query
+type BaseTable = qx.BaseTable
+type Predicate = qx.Predicate
+type Field = qx.Field
+type Fields = qx.Fields
+type FieldLiteral = qx.FieldLiteral
+type ValuesList = qx.ValuesList
+type Queryer = qx.Queryer
+type QueryerContext = qx.QueryerContext
+type Logger = qx.Logger
+type Window = qx.Window
+type Windows = qx.Windows
+type WindowFrame = qx.WindowFrame
+
+func RowNumber() qx.NumberField                 { return qx.RowNumber() }
+func Rank() qx.NumberField                      { return qx.Rank() }
+func DenseRank() qx.NumberField                 { return qx.DenseRank() }
+func Lag(field qx.Field, n int) qx.NumberField  { return qx.Lag(field, n) }
+func Lead(field qx.Field, n int) qx.NumberField { return qx.Lead(field, n) }
+func FirstValue(field qx.Field) qx.NumberField  { return qx.FirstValue(field) }
+func LastValue(field qx.Field) qx.NumberField   { return qx.LastValue(field) }
+func NthValue(field qx.Field, n int) qx.NumberField {
+	return qx.NthValue(field, n)
+}
+
+func CountAll() qx.NumberField                      { return qx.CountAll() }
+func Count(field qx.Field) qx.NumberField           { return qx.Count(field) }
+func CountDistinct(field qx.Field) qx.NumberField   { return qx.CountDistinct(field) }
+func Sum(field qx.NumberField) qx.NumberField       { return qx.Sum(field) }
+func Avg(field qx.NumberField) qx.NumberField       { return qx.Avg(field) }
+func Min(field qx.NumberField) qx.NumberField       { return qx.Min(field) }
+func Max(field qx.NumberField) qx.NumberField       { return qx.Max(field) }
+func MinString(field qx.StringField) qx.StringField { return qx.MinString(field) }
+func MaxString(field qx.StringField) qx.StringField { return qx.MaxString(field) }
+func MinTime(field qx.TimeField) qx.TimeField       { return qx.MinTime(field) }
+func MaxTime(field qx.TimeField) qx.TimeField       { return qx.MaxTime(field) }
+
+func NewCTE(name string, query qx.Query) qx.CTE {
+	return qx.CTE{
+		Name:  name,
+		Query: query,
+	}
+}
+
+func RecursiveCTE(name string, columns ...string) qx.CTE {
+	return qx.RecursiveCTE(name, columns...)
+}
+
+func And(predicates ...qx.Predicate) qx.Predicate {
+	return qx.VariadicPredicate{
+		Operator:   qx.PredicateAnd,
+		Predicates: predicates,
+	}
+}
+
+func Or(predicates ...qx.Predicate) qx.Predicate {
+	return qx.VariadicPredicate{
+		Operator:   qx.PredicateOr,
+		Predicates: predicates,
+	}
+}
+
+func Case(field qx.Field) qx.CaseExpr {
+	return qx.Case(field)
+}
+
+func CaseWhen(predicate qx.Predicate, result interface{}) qx.CaseExpr {
+	return qx.CaseWhen(predicate, result)
+}
+
+func Fieldf(format string, values ...interface{}) qx.CustomField {
+	return qx.CustomField{
+		Format: format,
+		Values: values,
+	}
+}
+
+func Predicatef(format string, values ...interface{}) qx.CustomPredicate {
+	return qx.CustomPredicate{
+		Format: format,
+		Values: values,
+	}
+}
+
+func Queryf(format string, values ...interface{}) qx.CustomQuery {
+	return qx.CustomQuery{
+		Format: format,
+		Values: values,
+	}
+}
+
+func EscapeLike(s string) string { return qx.EscapeLike(s) }
+
+func Coalesce(field qx.Field, values ...interface{}) qx.CustomField {
+	return qx.Coalesce(field, values...)
+}
+
+func CoalesceNumber(field qx.NumberField, values ...interface{}) qx.NumberField {
+	return qx.CoalesceNumber(field, values...)
+}
+
+func CoalesceString(field qx.StringField, values ...interface{}) qx.StringField {
+	return qx.CoalesceString(field, values...)
+}
+
+func CoalesceTime(field qx.TimeField, values ...interface{}) qx.TimeField {
+	return qx.CoalesceTime(field, values...)
+}
+
+func CoalesceBoolean(field qx.BooleanField, values ...interface{}) qx.BooleanField {
+	return qx.CoalesceBoolean(field, values...)
+}
+
+func NullIf(field qx.Field, value interface{}) qx.CustomField {
+	return qx.NullIf(field, value)
+}
+
+func NullIfNumber(field qx.NumberField, value interface{}) qx.NumberField {
+	return qx.NullIfNumber(field, value)
+}
+
+func NullIfString(field qx.StringField, value interface{}) qx.StringField {
+	return qx.NullIfString(field, value)
+}
+
+func NullIfTime(field qx.TimeField, value interface{}) qx.TimeField {
+	return qx.NullIfTime(field, value)
+}
+
+func NullIfBoolean(field qx.BooleanField, value interface{}) qx.BooleanField {
+	return qx.NullIfBoolean(field, value)
+}
+
+func Cast(field qx.Field, typ string) qx.CustomField {
+	return qx.Cast(field, typ)
+}
+
+func CastNumber(field qx.Field, typ string) qx.NumberField {
+	return qx.CastNumber(field, typ)
+}
+
+func CastString(field qx.Field, typ string) qx.StringField {
+	return qx.CastString(field, typ)
+}
+
+func CastTime(field qx.Field, typ string) qx.TimeField {
+	return qx.CastTime(field, typ)
+}
+
+func CastBoolean(field qx.Field, typ string) qx.BooleanField {
+	return qx.CastBoolean(field, typ)
+}
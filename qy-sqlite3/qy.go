@@ -0,0 +1,249 @@
+package qy
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+const (
+	LInterpolate = 1 << iota
+	LStats
+	LResults
+	LParse
+	LVerbose = LStats | LResults
+)
+
+type BaseQuery struct {
+	DB      qx.DB
+	Log     qx.Logger
+	LogFlag int
+	CTEs    qx.CTEs
+}
+
+func WithLog(logger qx.Logger, flag int) BaseQuery {
+	return BaseQuery{
+		Log:     logger,
+		LogFlag: flag,
+	}
+}
+
+func WithDB(db qx.DB) BaseQuery {
+	return BaseQuery{
+		DB: db,
+	}
+}
+
+func With(CTEs ...qx.CTE) BaseQuery {
+	return BaseQuery{
+		CTEs: CTEs,
+	}
+}
+
+func (qy BaseQuery) WithLog(logger qx.Logger, flag int) BaseQuery {
+	qy.Log = logger
+	qy.LogFlag = flag
+	return qy
+}
+
+func (qy BaseQuery) WithDB(db qx.DB) BaseQuery {
+	qy.DB = db
+	return qy
+}
+
+func (qy BaseQuery) With(CTEs ...qx.CTE) BaseQuery {
+	qy.CTEs = CTEs
+	return qy
+}
+
+func (qy BaseQuery) From(table qx.Table) SelectQuery {
+	return SelectQuery{
+		FromTable: table,
+		Alias:     qx.RandomString(8),
+		CTEs:      qy.CTEs,
+		DB:        qy.DB,
+		Log:       qy.Log,
+		LogFlag:   qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) Select(fields ...qx.Field) SelectQuery {
+	return SelectQuery{
+		SelectFields: fields,
+		Alias:        qx.RandomString(8),
+		CTEs:         qy.CTEs,
+		DB:           qy.DB,
+		Log:          qy.Log,
+		LogFlag:      qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) SelectOne() SelectQuery {
+	return SelectQuery{
+		SelectFields: qx.Fields{qx.FieldLiteral("1")},
+		Alias:        qx.RandomString(8),
+		CTEs:         qy.CTEs,
+		DB:           qy.DB,
+		Log:          qy.Log,
+		LogFlag:      qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) SelectAll() SelectQuery {
+	return SelectQuery{
+		SelectFields: qx.Fields{qx.FieldLiteral("*")},
+		Alias:        qx.RandomString(8),
+		CTEs:         qy.CTEs,
+		DB:           qy.DB,
+		Log:          qy.Log,
+		LogFlag:      qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) SelectCount() SelectQuery {
+	return SelectQuery{
+		SelectFields: qx.Fields{qx.CountAll()},
+		Alias:        qx.RandomString(8),
+		CTEs:         qy.CTEs,
+		DB:           qy.DB,
+		Log:          qy.Log,
+		LogFlag:      qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) SelectDistinct(fields ...qx.Field) SelectQuery {
+	return SelectQuery{
+		SelectType:   qx.SelectTypeDistinct,
+		SelectFields: fields,
+		Alias:        qx.RandomString(8),
+		CTEs:         qy.CTEs,
+		DB:           qy.DB,
+		Log:          qy.Log,
+		LogFlag:      qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) Selectx(mapper func(Row), accumulator func()) SelectQuery {
+	return SelectQuery{
+		Mapper:      mapper,
+		Accumulator: accumulator,
+		Alias:       qx.RandomString(8),
+		CTEs:        qy.CTEs,
+		DB:          qy.DB,
+		Log:         qy.Log,
+		LogFlag:     qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) SelectRowx(mapper func(Row)) SelectQuery {
+	return SelectQuery{
+		Mapper:  mapper,
+		Alias:   qx.RandomString(8),
+		CTEs:    qy.CTEs,
+		DB:      qy.DB,
+		Log:     qy.Log,
+		LogFlag: qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) InsertInto(table qx.BaseTable) InsertQuery {
+	return InsertQuery{
+		IntoTable: table,
+		Alias:     qx.RandomString(8),
+		CTEs:      qy.CTEs,
+		DB:        qy.DB,
+		Log:       qy.Log,
+		LogFlag:   qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) InsertOrReplaceInto(table qx.BaseTable) InsertQuery {
+	return InsertQuery{
+		InsertType: InsertTypeOrReplace,
+		IntoTable:  table,
+		Alias:      qx.RandomString(8),
+		CTEs:       qy.CTEs,
+		DB:         qy.DB,
+		Log:        qy.Log,
+		LogFlag:    qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) InsertOrIgnoreInto(table qx.BaseTable) InsertQuery {
+	return InsertQuery{
+		InsertType: InsertTypeOrIgnore,
+		IntoTable:  table,
+		Alias:      qx.RandomString(8),
+		CTEs:       qy.CTEs,
+		DB:         qy.DB,
+		Log:        qy.Log,
+		LogFlag:    qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) Update(table qx.BaseTable) UpdateQuery {
+	return UpdateQuery{
+		UpdateTable: table,
+		Alias:       qx.RandomString(8),
+		CTEs:        qy.CTEs,
+		DB:          qy.DB,
+		Log:         qy.Log,
+		LogFlag:     qy.LogFlag,
+	}
+}
+
+func (qy BaseQuery) DeleteFrom(table qx.BaseTable) DeleteQuery {
+	return DeleteQuery{
+		FromTable: table,
+		Alias:     qx.RandomString(8),
+		CTEs:      qy.CTEs,
+		DB:        qy.DB,
+		Log:       qy.Log,
+		LogFlag:   qy.LogFlag,
+	}
+}
+
+type Row interface {
+	ScanInto(dest interface{}, field qx.Field)
+	// bool
+	Bool(qx.BooleanField) bool
+	Bool_(qx.Field) bool
+	BoolValid(qx.BooleanField) bool
+	BoolValid_(qx.Field) bool
+	NullBool(qx.BooleanField) sql.NullBool
+	NullBool_(qx.Field) sql.NullBool
+	// float64
+	Float64(qx.NumberField) float64
+	Float64_(qx.Field) float64
+	Float64Valid(qx.NumberField) bool
+	Float64Valid_(qx.Field) bool
+	NullFloat64(qx.NumberField) sql.NullFloat64
+	NullFloat64_(qx.Field) sql.NullFloat64
+	// int
+	Int(qx.NumberField) int
+	Int_(qx.Field) int
+	IntValid(qx.NumberField) bool
+	IntValid_(qx.Field) bool
+	// int64
+	Int64(qx.NumberField) int64
+	Int64_(qx.Field) int64
+	Int64Valid(qx.NumberField) bool
+	Int64Valid_(qx.Field) bool
+	NullInt64(qx.NumberField) sql.NullInt64
+	NullInt64_(qx.Field) sql.NullInt64
+	// string
+	String(qx.StringField) string
+	String_(qx.Field) string
+	StringValid(qx.StringField) bool
+	StringValid_(qx.Field) bool
+	NullString(qx.StringField) sql.NullString
+	NullString_(qx.Field) sql.NullString
+	// time.Time
+	Time(qx.TimeField) time.Time
+	Time_(qx.Field) time.Time
+	TimeValid(qx.TimeField) bool
+	TimeValid_(qx.Field) bool
+	NullTime(qx.TimeField) sql.NullTime
+	NullTime_(qx.Field) sql.NullTime
+}
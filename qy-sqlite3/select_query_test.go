@@ -0,0 +1,62 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSelectQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           SelectQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "joins, where, group by, having, order by, limit and offset"
+			cust, addr, city := CUSTOMER().As("cust"), ADDRESS().As("addr"), CITY()
+			q := Select(city.CITY, Fieldf("COUNT(*)")).
+				From(cust).
+				Join(addr, addr.ADDRESS_ID.Eq(cust.ADDRESS_ID)).
+				LeftJoin(city, city.CITY_ID.Eq(addr.CITY_ID)).
+				Where(cust.ACTIVEBOOL, cust.FIRST_NAME.LikeString("A%")).
+				GroupBy(city.CITY).
+				Having(Predicatef("COUNT(*) > ?", 5)).
+				OrderBy(city.CITY.Desc()).
+				Limit(10).
+				Offset(20)
+			wantQuery := "SELECT city.city, COUNT(*)" +
+				" FROM customer AS cust" +
+				" JOIN address AS addr ON addr.address_id = cust.address_id" +
+				" LEFT JOIN city ON city.city_id = addr.city_id" +
+				" WHERE cust.activebool AND cust.first_name LIKE ?" +
+				" GROUP BY city.city" +
+				" HAVING COUNT(*) > ?" +
+				" ORDER BY city.city DESC" +
+				" LIMIT ? OFFSET ?"
+			wantArgs := []interface{}{"A%", 5, uint64(10), uint64(20)}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "SelectDistinct with CTE"
+			actor := ACTOR()
+			cte := NewCTE("actors", Select(actor.ACTOR_ID, actor.FIRST_NAME).From(actor))
+			q := SelectDistinct(cte.Get("first_name")).With(cte).From(cte)
+			wantQuery := "WITH actors AS (SELECT actor.actor_id, actor.first_name FROM actor)" +
+				" SELECT DISTINCT actors.first_name FROM actors"
+			return TT{DESCRIPTION, q, wantQuery, nil}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
@@ -0,0 +1,423 @@
+package qy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bokwoon95/qy/qx"
+)
+
+type UpdateQuery struct {
+	Nested  bool
+	Alias   string
+	Dialect qx.Dialect
+	// WITH
+	CTEs qx.CTEs
+	// UPDATE
+	UpdateTable qx.BaseTable
+	// SET
+	SetFields qx.FieldValueSets
+	// FROM
+	FromTable  qx.Table
+	JoinGroups qx.JoinTables
+	// WHERE
+	WherePredicates qx.VariadicPredicate
+	// RETURNING
+	ReturningFields qx.Fields
+	// ORDER BY
+	OrderByFields qx.Fields
+	// LIMIT
+	LimitValue *uint64
+	// DB
+	DB          qx.DB
+	Mapper      func(Row)
+	Accumulator func()
+	// Logging
+	Log     qx.Logger
+	LogFlag int
+	LogSkip int
+}
+
+func (q UpdateQuery) ToSQL() (string, []interface{}) {
+	var buf = &strings.Builder{}
+	var args []interface{}
+	var excludeTableQualifiers []string
+	// WITH
+	q.CTEs.WriteSQL(buf, &args)
+	{ // UPDATE
+		updateQuery, updateArgs := "", []interface{}{}
+		if q.UpdateTable != nil {
+			updateQuery, updateArgs = q.UpdateTable.ToSQL()
+			if q.UpdateTable.GetAlias() != "" {
+				excludeTableQualifiers = append(excludeTableQualifiers, q.UpdateTable.GetAlias())
+			} else if q.UpdateTable.GetName() != "" {
+				excludeTableQualifiers = append(excludeTableQualifiers, q.UpdateTable.GetName())
+			}
+		}
+		if updateQuery != "" {
+			if buf.Len() > 0 {
+				buf.WriteString(" ")
+			}
+			if q.UpdateTable.GetAlias() != "" {
+				buf.WriteString("UPDATE " + updateQuery + " AS " + qx.TableAlias(q.UpdateTable))
+			} else {
+				buf.WriteString("UPDATE " + updateQuery)
+			}
+			args = append(args, updateArgs...)
+		}
+	}
+	// SET
+	q.SetFields.WriteSQL(buf, &args, "SET ", "", excludeTableQualifiers)
+	{ // FROM
+		fromQuery, fromArgs := "", []interface{}{}
+		if q.FromTable != nil {
+			fromQuery, fromArgs = q.FromTable.ToSQL()
+		}
+		if fromQuery != "" {
+			if buf.Len() > 0 {
+				buf.WriteString(" ")
+			}
+			if q.FromTable.GetAlias() != "" {
+				buf.WriteString("FROM " + fromQuery + " AS " + qx.TableAlias(q.FromTable))
+			} else {
+				buf.WriteString("FROM " + fromQuery)
+			}
+			args = append(args, fromArgs...)
+		}
+	}
+	// JOIN
+	q.JoinGroups.WriteSQL(buf, &args)
+	// WHERE
+	q.WherePredicates.Toplevel = true
+	q.WherePredicates.WriteSQL(buf, &args, "WHERE ", "", nil)
+	// RETURNING
+	q.ReturningFields.WriteSQLWithAlias(buf, &args, "RETURNING ", "", nil)
+	// ORDER BY
+	q.OrderByFields.WriteSQL(buf, &args, "ORDER BY ", "", nil)
+	// LIMIT
+	if q.LimitValue != nil {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("LIMIT ?")
+		args = append(args, *q.LimitValue)
+	}
+	query := buf.String()
+	if !q.Nested {
+		if q.Dialect == nil {
+			q.Dialect = qx.SQLite
+		}
+		query = qx.Rebind(q.Dialect, query)
+		if q.Log != nil {
+			var logOutput string
+			switch {
+			case LStats&q.LogFlag != 0:
+				logOutput = "\n----[ Executing query ]----\n" + query + " " + fmt.Sprint(args) +
+					"\n----[ with bind values ]----\n" + q.Dialect.InterpolateSQL(query, args...)
+			case LInterpolate&q.LogFlag != 0:
+				logOutput = "Executing query: " + q.Dialect.InterpolateSQL(query, args...)
+			default:
+				logOutput = "Executing query: " + query + " " + fmt.Sprint(args)
+			}
+			switch q.Log.(type) {
+			case *log.Logger:
+				q.Log.Output(q.LogSkip+2, logOutput)
+			default:
+				q.Log.Output(q.LogSkip+1, logOutput)
+			}
+		}
+	}
+	return query, args
+}
+
+// ToSQLDialect marshals the UpdateQuery into an SQL query for the given dialect.
+func (q UpdateQuery) ToSQLDialect(dialect qx.Dialect) (string, []interface{}) {
+	q.Dialect = dialect
+	q.LogSkip += 1
+	return q.ToSQL()
+}
+
+func (q UpdateQuery) GetAlias() string {
+	return q.Alias
+}
+
+func (q UpdateQuery) GetName() string {
+	return ""
+}
+
+func (q UpdateQuery) NestThis() qx.Query {
+	q.Nested = true
+	return q
+}
+
+func (q UpdateQuery) As(alias string) UpdateQuery {
+	q.Alias = alias
+	return q
+}
+
+func Update(table qx.BaseTable) UpdateQuery {
+	return UpdateQuery{
+		UpdateTable: table,
+		Alias:       qx.RandomString(8),
+	}
+}
+
+func (q UpdateQuery) With(cteList ...qx.CTE) UpdateQuery {
+	q.CTEs = append(q.CTEs, cteList...)
+	return q
+}
+
+func (q UpdateQuery) Update(tbl qx.BaseTable) UpdateQuery {
+	q.UpdateTable = tbl
+	return q
+}
+
+func (q UpdateQuery) Set(sets ...qx.FieldValueSet) UpdateQuery {
+	q.SetFields = append(q.SetFields, sets...)
+	return q
+}
+
+func (q UpdateQuery) From(tbl qx.Table) UpdateQuery {
+	q.FromTable = tbl
+	return q
+}
+
+func (q UpdateQuery) Join(tbl qx.Table, pred qx.Predicate, preds ...qx.Predicate) UpdateQuery {
+	preds = append([]qx.Predicate{pred}, preds...)
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeDefault,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) LeftJoin(tbl qx.Table, pred qx.Predicate, preds ...qx.Predicate) UpdateQuery {
+	preds = append([]qx.Predicate{pred}, preds...)
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeLeft,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) RightJoin(tbl qx.Table, pred qx.Predicate, preds ...qx.Predicate) UpdateQuery {
+	preds = append([]qx.Predicate{pred}, preds...)
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeRight,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) FullJoin(tbl qx.Table, pred qx.Predicate, preds ...qx.Predicate) UpdateQuery {
+	preds = append([]qx.Predicate{pred}, preds...)
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:     qx.JoinTypeFull,
+		Table:        tbl,
+		OnPredicates: qx.VariadicPredicate{Predicates: preds},
+	})
+	return q
+}
+
+func (q UpdateQuery) CrossJoin(tbl qx.Table) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType: qx.JoinTypeCross,
+		Table:    tbl,
+	})
+	return q
+}
+
+func (q UpdateQuery) JoinUsing(tbl qx.Table, fields ...qx.Field) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType:    qx.JoinTypeDefault,
+		Table:       tbl,
+		UsingFields: fields,
+	})
+	return q
+}
+
+func (q UpdateQuery) NaturalJoin(tbl qx.Table) UpdateQuery {
+	q.JoinGroups = append(q.JoinGroups, qx.JoinTable{
+		JoinType: qx.JoinTypeNatural,
+		Table:    tbl,
+	})
+	return q
+}
+
+func (q UpdateQuery) Where(preds ...qx.Predicate) UpdateQuery {
+	q.WherePredicates.Predicates = append(q.WherePredicates.Predicates, preds...)
+	return q
+}
+
+// OrderBy is only available if SQLite was compiled with
+// SQLITE_ENABLE_UPDATE_DELETE_LIMIT.
+func (q UpdateQuery) OrderBy(fields ...qx.Field) UpdateQuery {
+	q.OrderByFields = append(q.OrderByFields, fields...)
+	return q
+}
+
+// Limit is only available if SQLite was compiled with
+// SQLITE_ENABLE_UPDATE_DELETE_LIMIT.
+func (q UpdateQuery) Limit(limit int) UpdateQuery {
+	if limit < 0 {
+		limit = -limit
+	}
+	num := uint64(limit)
+	q.LimitValue = &num
+	return q
+}
+
+func (q UpdateQuery) Returning(fields ...qx.Field) UpdateQuery {
+	q.ReturningFields = append(q.ReturningFields, fields...)
+	return q
+}
+
+func (q UpdateQuery) ReturningOne() UpdateQuery {
+	q.ReturningFields = qx.Fields{qx.FieldLiteral("1")}
+	return q
+}
+
+func (q UpdateQuery) Returningx(mapper func(Row), accumulator func()) UpdateQuery {
+	q.Mapper = mapper
+	q.Accumulator = accumulator
+	return q
+}
+
+func (q UpdateQuery) ReturningRowx(mapper func(Row)) UpdateQuery {
+	q.Mapper = mapper
+	return q
+}
+
+func (q UpdateQuery) Fetch(db qx.DB) (err error) {
+	q.LogSkip += 1
+	return q.FetchContext(nil, db)
+}
+
+func (q UpdateQuery) FetchContext(ctx context.Context, db qx.DB) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			case string:
+				err = errors.New(v)
+			}
+		}
+	}()
+	logBuf := &strings.Builder{}
+	var rowcount int
+	defer func() func() {
+		var logskip int
+		switch q.Log.(type) {
+		case *log.Logger:
+			logskip = q.LogSkip + 2
+		default:
+			logskip = q.LogSkip + 1
+		}
+		start := time.Now()
+		return func() {
+			elapsed := time.Since(start)
+			if LResults&q.LogFlag != 0 && q.Log != nil && rowcount > 5 {
+				logBuf.WriteString("\n...")
+			}
+			if LStats&q.LogFlag != 0 && q.Log != nil {
+				logBuf.WriteString("\n(Fetched " + strconv.Itoa(rowcount) + " rows in " + elapsed.String() + ")")
+			}
+			if logBuf.Len() > 0 && q.Log != nil {
+				q.Log.Output(logskip, logBuf.String())
+			}
+		}
+	}()()
+	if db == nil {
+		if q.DB == nil {
+			return errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	r := &qx.QxRow{}
+	if q.Mapper != nil {
+		q.Mapper(r) // call the mapper once on the *Row to get all the selected that the user is interested in
+	}
+	q.ReturningFields = r.Fields // then, transfer the selected collected by *Row to the UpdateQuery
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		r.Rows, err = db.Query(query, args...)
+	} else {
+		r.Rows, err = db.QueryContext(ctx, query, args...)
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Rows.Close()
+	if len(r.Dest) == 0 {
+		// If there's nothing to scan into, return early
+		return nil
+	}
+	for r.Rows.Next() {
+		rowcount++
+		err = r.Rows.Scan(r.Dest...)
+		if err != nil {
+			buf := &strings.Builder{}
+			for i := range r.Dest {
+				query, args := r.Fields[i].ToSQLExclude(nil)
+				buf.WriteString("\n" +
+					strconv.Itoa(i) + ") " +
+					qx.MySQLInterpolateSQL(query, args...) + " => " +
+					reflect.TypeOf(r.Dest[i]).String())
+			}
+			return fmt.Errorf("Please check if your mapper function is correct:%s\n%w", buf.String(), err)
+		}
+		if LResults&q.LogFlag != 0 && q.Log != nil && rowcount <= 5 {
+			logBuf.WriteString("\n----[ Row " + strconv.Itoa(rowcount) + " ]----")
+			for i := range r.Dest {
+				q, a := r.Fields[i].ToSQLExclude(nil)
+				logBuf.WriteString("\n" + qx.MySQLInterpolateSQL(q, a...) + ": " + qx.ArgToStringV2(r.Dest[i]))
+			}
+		}
+		r.Index = 0 // index must always be reset back to 0 before mapper is called
+		q.Mapper(r)
+		if q.Accumulator == nil {
+			break
+		}
+		q.Accumulator()
+	}
+	if rowcount == 0 && q.Accumulator == nil {
+		return sql.ErrNoRows
+	}
+	return r.Rows.Err()
+}
+
+func (q UpdateQuery) Exec(db qx.DB) (sql.Result, error) {
+	q.LogSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+func (q UpdateQuery) ExecContext(ctx context.Context, db qx.DB) (sql.Result, error) {
+	var res sql.Result
+	var err error
+	if db == nil {
+		if q.DB == nil {
+			return res, errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	q.LogSkip += 1
+	query, args := q.ToSQL()
+	if ctx == nil {
+		res, err = db.Exec(query, args...)
+	} else {
+		res, err = db.ExecContext(ctx, query, args...)
+	}
+	return res, err
+}
@@ -0,0 +1,54 @@
+package qy
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestUpdateQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		DESCRIPTION string
+		q           UpdateQuery
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	tests := []TT{
+		func() TT {
+			DESCRIPTION := "update with returning, order by and limit"
+			actor := ACTOR()
+			q := Update(actor).
+				Set(actor.FIRST_NAME.Set("PENELOPE")).
+				Where(actor.LAST_NAME.EqString("GUINESS")).
+				Returning(actor.ACTOR_ID).
+				OrderBy(actor.ACTOR_ID).
+				Limit(1)
+			wantQuery := "UPDATE actor SET first_name = ? WHERE actor.last_name = ?" +
+				" RETURNING actor.actor_id ORDER BY actor.actor_id LIMIT ?"
+			wantArgs := []interface{}{"PENELOPE", "GUINESS", uint64(1)}
+			return TT{DESCRIPTION, q, wantQuery, wantArgs}
+		}(),
+		func() TT {
+			DESCRIPTION := "update from"
+			cust, addr := CUSTOMER().As("cust"), ADDRESS().As("addr")
+			q := Update(cust).
+				Set(cust.ACTIVEBOOL.Set(false)).
+				From(addr).
+				Where(addr.ADDRESS_ID.Eq(cust.ADDRESS_ID), addr.DISTRICT.EqString("Alberta"))
+			wantQuery := "UPDATE customer AS cust SET activebool = ?" +
+				" FROM address AS addr" +
+				" WHERE addr.address_id = cust.address_id AND addr.district = ?"
+			return TT{DESCRIPTION, q, wantQuery, []interface{}{false, "Alberta"}}
+		}(),
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.DESCRIPTION, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}